@@ -17,8 +17,10 @@ Copyright (C) 2021 fcbrooks
 package main
 
 import (
+	"archive/zip"
 	"bytes"
 	"database/sql"
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -32,6 +34,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 const TestDataRoot = "testdata"
@@ -39,6 +42,17 @@ const EmptyPlaceholder = ".empty"
 
 func TestAll(t *testing.T) {
 
+	// keep the catalog of the user running the tests out of them
+	configDir, err := ioutil.TempDir("", "ampt-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(configDir)
+	for _, name := range []string{"HOME", "XDG_CONFIG_HOME", "AppData"} {
+		defer os.Setenv(name, os.Getenv(name))
+		os.Setenv(name, configDir)
+	}
+
 	for _, tc := range []struct {
 		Name             string
 		Command          string
@@ -347,11 +361,9 @@ Amplitube\SVX:
 				target := filepath.Join(workingDir, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension)
 				sourceData, _ := ioutil.ReadFile(source)
 				targetData, _ := ioutil.ReadFile(target)
-				var sourceXml PresetXMLV5
-				var targetXml PresetXMLV5
-				xml.Unmarshal(sourceData, &sourceXml)
-				xml.Unmarshal(targetData, &targetXml)
-				if sourceXml.AmpA.Model != targetXml.AmpA.Model {
+				sourceXml, _ := parsePreset(sourceData)
+				targetXml, _ := parsePreset(targetData)
+				if sourceXml.child("AmpA").attr("Model") != targetXml.child("AmpA").attr("Model") {
 					return errors.New("source and target have different AmpA; expected same")
 				}
 				return nil
@@ -1186,19 +1198,17 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpA.Model != preset2.AmpA.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpA.Model + "; was " + preset2.AmpA.Model)
+				if preset1.child("AmpA").attr("Model") != preset2.child("AmpA").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpA").attr("Model") + "; was " + preset2.child("AmpA").attr("Model"))
 				}
-				if !reflect.DeepEqual(preset1.AmpA.Amp, preset2.AmpA.Amp) {
-					amp1, _ := xml.Marshal(preset1.AmpA.Amp)
-					amp2, _ := xml.Marshal(preset1.AmpA.Amp)
+				if !elementsEqual(preset1.child("AmpA").child("Amp"), preset2.child("AmpA").child("Amp")) {
+					amp1, _ := xml.Marshal(preset1.child("AmpA").child("Amp"))
+					amp2, _ := xml.Marshal(preset1.child("AmpA").child("Amp"))
 					return errors.New("amp details do not match; expected " + string(selfClose(amp1)) + "; was " + string(selfClose(amp2)))
 				}
 				return nil
@@ -1217,19 +1227,17 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpB.Model != preset2.AmpB.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpB.Model + "; was " + preset2.AmpB.Model)
+				if preset1.child("AmpB").attr("Model") != preset2.child("AmpB").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpB").attr("Model") + "; was " + preset2.child("AmpB").attr("Model"))
 				}
-				if !reflect.DeepEqual(preset1.AmpB.Amp, preset2.AmpB.Amp) {
-					amp1, _ := xml.Marshal(preset1.AmpB.Amp)
-					amp2, _ := xml.Marshal(preset1.AmpB.Amp)
+				if !elementsEqual(preset1.child("AmpB").child("Amp"), preset2.child("AmpB").child("Amp")) {
+					amp1, _ := xml.Marshal(preset1.child("AmpB").child("Amp"))
+					amp2, _ := xml.Marshal(preset1.child("AmpB").child("Amp"))
 					return errors.New("amp details do not match; expected " + string(selfClose(amp1)) + "; was " + string(selfClose(amp2)))
 				}
 				return nil
@@ -1248,19 +1256,17 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpC.Model != preset2.AmpC.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpC.Model + "; was " + preset2.AmpC.Model)
+				if preset1.child("AmpC").attr("Model") != preset2.child("AmpC").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpC").attr("Model") + "; was " + preset2.child("AmpC").attr("Model"))
 				}
-				if !reflect.DeepEqual(preset1.AmpC.Amp, preset2.AmpC.Amp) {
-					amp1, _ := xml.Marshal(preset1.AmpC.Amp)
-					amp2, _ := xml.Marshal(preset1.AmpC.Amp)
+				if !elementsEqual(preset1.child("AmpC").child("Amp"), preset2.child("AmpC").child("Amp")) {
+					amp1, _ := xml.Marshal(preset1.child("AmpC").child("Amp"))
+					amp2, _ := xml.Marshal(preset1.child("AmpC").child("Amp"))
 					return errors.New("amp details do not match; expected " + string(selfClose(amp1)) + "; was " + string(selfClose(amp2)))
 				}
 				return nil
@@ -1279,19 +1285,17 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.CabA.CabModel != preset2.CabA.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabA.CabModel + "; was " + preset2.CabA.CabModel)
+				if preset1.child("CabA").attr("CabModel") != preset2.child("CabA").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabA").attr("CabModel") + "; was " + preset2.child("CabA").attr("CabModel"))
 				}
-				if !reflect.DeepEqual(preset1.CabA.Cab, preset2.CabA.Cab) {
-					cab1, _ := xml.Marshal(preset1.CabA.Cab)
-					cab2, _ := xml.Marshal(preset2.CabA.Cab)
+				if !elementsEqual(preset1.child("CabA").child("Cab"), preset2.child("CabA").child("Cab")) {
+					cab1, _ := xml.Marshal(preset1.child("CabA").child("Cab"))
+					cab2, _ := xml.Marshal(preset2.child("CabA").child("Cab"))
 					return errors.New("cab details do not match; expected " + string(selfClose(cab1)) + "; was " + string(selfClose(cab2)))
 				}
 				return nil
@@ -1310,19 +1314,17 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.CabB.CabModel != preset2.CabB.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabB.CabModel + "; was " + preset2.CabB.CabModel)
+				if preset1.child("CabB").attr("CabModel") != preset2.child("CabB").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabB").attr("CabModel") + "; was " + preset2.child("CabB").attr("CabModel"))
 				}
-				if !reflect.DeepEqual(preset1.CabB.Cab, preset2.CabB.Cab) {
-					cab1, _ := xml.Marshal(preset1.CabB.Cab)
-					cab2, _ := xml.Marshal(preset2.CabB.Cab)
+				if !elementsEqual(preset1.child("CabB").child("Cab"), preset2.child("CabB").child("Cab")) {
+					cab1, _ := xml.Marshal(preset1.child("CabB").child("Cab"))
+					cab2, _ := xml.Marshal(preset2.child("CabB").child("Cab"))
 					return errors.New("cab details do not match; expected " + string(selfClose(cab1)) + "; was " + string(selfClose(cab2)))
 				}
 				return nil
@@ -1341,19 +1343,17 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.CabC.CabModel != preset2.CabC.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabC.CabModel + "; was " + preset2.CabC.CabModel)
+				if preset1.child("CabC").attr("CabModel") != preset2.child("CabC").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabC").attr("CabModel") + "; was " + preset2.child("CabC").attr("CabModel"))
 				}
-				if !reflect.DeepEqual(preset1.CabC.Cab, preset2.CabC.Cab) {
-					cab1, _ := xml.Marshal(preset1.CabC.Cab)
-					cab2, _ := xml.Marshal(preset2.CabC.Cab)
+				if !elementsEqual(preset1.child("CabC").child("Cab"), preset2.child("CabC").child("Cab")) {
+					cab1, _ := xml.Marshal(preset1.child("CabC").child("Cab"))
+					cab2, _ := xml.Marshal(preset2.child("CabC").child("Cab"))
 					return errors.New("cab details do not match; expected " + string(selfClose(cab1)) + "; was " + string(selfClose(cab2)))
 				}
 				return nil
@@ -1373,15 +1373,13 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpA.Model != preset2.AmpB.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpA.Model + "; was " + preset2.AmpB.Model)
+				if preset1.child("AmpA").attr("Model") != preset2.child("AmpB").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpA").attr("Model") + "; was " + preset2.child("AmpB").attr("Model"))
 				}
 				return nil
 			},
@@ -1400,15 +1398,13 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.CabA.CabModel != preset2.CabB.CabModel {
-					return errors.New("amps do not match; expected " + preset1.CabA.CabModel + "; was " + preset2.CabB.CabModel)
+				if preset1.child("CabA").attr("CabModel") != preset2.child("CabB").attr("CabModel") {
+					return errors.New("amps do not match; expected " + preset1.child("CabA").attr("CabModel") + "; was " + preset2.child("CabB").attr("CabModel"))
 				}
 				return nil
 			},
@@ -1426,27 +1422,25 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpA.Model != preset2.AmpA.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpA.Model + "; was " + preset2.AmpA.Model)
+				if preset1.child("AmpA").attr("Model") != preset2.child("AmpA").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpA").attr("Model") + "; was " + preset2.child("AmpA").attr("Model"))
 				}
-				if preset1.CabA.CabModel != preset2.CabA.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabA.CabModel + "; was " + preset2.CabA.CabModel)
+				if preset1.child("CabA").attr("CabModel") != preset2.child("CabA").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabA").attr("CabModel") + "; was " + preset2.child("CabA").attr("CabModel"))
 				}
-				if !reflect.DeepEqual(preset1.AmpA.Amp, preset2.AmpA.Amp) {
-					amp1, _ := xml.Marshal(preset1.AmpA.Amp)
-					amp2, _ := xml.Marshal(preset1.AmpA.Amp)
+				if !elementsEqual(preset1.child("AmpA").child("Amp"), preset2.child("AmpA").child("Amp")) {
+					amp1, _ := xml.Marshal(preset1.child("AmpA").child("Amp"))
+					amp2, _ := xml.Marshal(preset1.child("AmpA").child("Amp"))
 					return errors.New("amp details do not match; expected " + string(selfClose(amp1)) + "; was " + string(selfClose(amp2)))
 				}
-				if !reflect.DeepEqual(preset1.CabA.CabModel, preset2.CabA.CabModel) {
-					cab1, _ := xml.Marshal(preset1.CabA.CabModel)
-					cab2, _ := xml.Marshal(preset2.CabA.CabModel)
+				if !reflect.DeepEqual(preset1.child("CabA").attr("CabModel"), preset2.child("CabA").attr("CabModel")) {
+					cab1, _ := xml.Marshal(preset1.child("CabA").attr("CabModel"))
+					cab2, _ := xml.Marshal(preset2.child("CabA").attr("CabModel"))
 					return errors.New("cab details do not match; expected " + string(selfClose(cab1)) + "; was " + string(selfClose(cab2)))
 				}
 				return nil
@@ -1466,27 +1460,25 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpA.Model != preset2.AmpB.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpA.Model + "; was " + preset2.AmpA.Model)
+				if preset1.child("AmpA").attr("Model") != preset2.child("AmpB").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpA").attr("Model") + "; was " + preset2.child("AmpA").attr("Model"))
 				}
-				if preset1.CabA.CabModel != preset2.CabB.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabA.CabModel + "; was " + preset2.CabB.CabModel)
+				if preset1.child("CabA").attr("CabModel") != preset2.child("CabB").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabA").attr("CabModel") + "; was " + preset2.child("CabB").attr("CabModel"))
 				}
-				if !reflect.DeepEqual(preset1.AmpA.Amp, preset2.AmpB.Amp) {
-					amp1, _ := xml.Marshal(preset1.AmpA.Amp)
-					amp2, _ := xml.Marshal(preset1.AmpB.Amp)
+				if !elementsEqual(preset1.child("AmpA").child("Amp"), preset2.child("AmpB").child("Amp")) {
+					amp1, _ := xml.Marshal(preset1.child("AmpA").child("Amp"))
+					amp2, _ := xml.Marshal(preset1.child("AmpB").child("Amp"))
 					return errors.New("amp details do not match; expected " + string(selfClose(amp1)) + "; was " + string(selfClose(amp2)))
 				}
-				if !reflect.DeepEqual(preset1.CabA.CabModel, preset2.CabB.CabModel) {
-					cab1, _ := xml.Marshal(preset1.CabA.CabModel)
-					cab2, _ := xml.Marshal(preset2.CabB.CabModel)
+				if !reflect.DeepEqual(preset1.child("CabA").attr("CabModel"), preset2.child("CabB").attr("CabModel")) {
+					cab1, _ := xml.Marshal(preset1.child("CabA").attr("CabModel"))
+					cab2, _ := xml.Marshal(preset2.child("CabB").attr("CabModel"))
 					return errors.New("cab details do not match; expected " + string(selfClose(cab1)) + "; was " + string(selfClose(cab2)))
 				}
 				return nil
@@ -1506,18 +1498,16 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpA.Model != preset2.AmpA.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpA.Model + "; was " + preset2.AmpA.Model)
+				if preset1.child("AmpA").attr("Model") != preset2.child("AmpA").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpA").attr("Model") + "; was " + preset2.child("AmpA").attr("Model"))
 				}
-				if preset1.CabA.CabModel == preset2.CabA.CabModel {
-					return errors.New("cabs match; both were " + preset1.CabA.CabModel)
+				if preset1.child("CabA").attr("CabModel") == preset2.child("CabA").attr("CabModel") {
+					return errors.New("cabs match; both were " + preset1.child("CabA").attr("CabModel"))
 				}
 				return nil
 			},
@@ -1536,61 +1526,59 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA1
-				stomp2 := preset2.StompA1
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompA1")
+				stomp2 := preset2.child("StompA1")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if stomp1.Stomp4 != stomp2.Stomp4 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp4 + "; was " + stomp2.Stomp4)
+				if stomp1.attr("Stomp4") != stomp2.attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp4") + "; was " + stomp2.attr("Stomp4"))
 				}
-				if stomp1.Stomp5 != stomp2.Stomp5 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp5 + "; was " + stomp2.Stomp5)
+				if stomp1.attr("Stomp5") != stomp2.attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp5") + "; was " + stomp2.attr("Stomp5"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3, stomp2.Slot3) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(stomp1.child("Slot3"), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot4, stomp2.Slot4) {
-					slot1, _ := xml.Marshal(stomp1.Slot4)
-					slot2, _ := xml.Marshal(stomp2.Slot4)
+				if !elementsEqual(stomp1.child("Slot4"), stomp2.child("Slot4")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot4"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot4"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot5, stomp2.Slot5) {
-					slot1, _ := xml.Marshal(stomp1.Slot5)
-					slot2, _ := xml.Marshal(stomp2.Slot5)
+				if !elementsEqual(stomp1.child("Slot5"), stomp2.child("Slot5")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot5"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot5"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -1610,61 +1598,59 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA2
-				stomp2 := preset2.StompA2
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompA2")
+				stomp2 := preset2.child("StompA2")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if stomp1.Stomp4 != stomp2.Stomp4 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp4 + "; was " + stomp2.Stomp4)
+				if stomp1.attr("Stomp4") != stomp2.attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp4") + "; was " + stomp2.attr("Stomp4"))
 				}
-				if stomp1.Stomp5 != stomp2.Stomp5 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp5 + "; was " + stomp2.Stomp5)
+				if stomp1.attr("Stomp5") != stomp2.attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp5") + "; was " + stomp2.attr("Stomp5"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3, stomp2.Slot3) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(stomp1.child("Slot3"), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot4, stomp2.Slot4) {
-					slot1, _ := xml.Marshal(stomp1.Slot4)
-					slot2, _ := xml.Marshal(stomp2.Slot4)
+				if !elementsEqual(stomp1.child("Slot4"), stomp2.child("Slot4")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot4"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot4"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot5, stomp2.Slot5) {
-					slot1, _ := xml.Marshal(stomp1.Slot5)
-					slot2, _ := xml.Marshal(stomp2.Slot5)
+				if !elementsEqual(stomp1.child("Slot5"), stomp2.child("Slot5")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot5"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot5"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -1684,61 +1670,59 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompB1
-				stomp2 := preset2.StompB1
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompB1")
+				stomp2 := preset2.child("StompB1")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if stomp1.Stomp4 != stomp2.Stomp4 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp4 + "; was " + stomp2.Stomp4)
+				if stomp1.attr("Stomp4") != stomp2.attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp4") + "; was " + stomp2.attr("Stomp4"))
 				}
-				if stomp1.Stomp5 != stomp2.Stomp5 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp5 + "; was " + stomp2.Stomp5)
+				if stomp1.attr("Stomp5") != stomp2.attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp5") + "; was " + stomp2.attr("Stomp5"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3, stomp2.Slot3) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(stomp1.child("Slot3"), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot4, stomp2.Slot4) {
-					slot1, _ := xml.Marshal(stomp1.Slot4)
-					slot2, _ := xml.Marshal(stomp2.Slot4)
+				if !elementsEqual(stomp1.child("Slot4"), stomp2.child("Slot4")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot4"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot4"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot5, stomp2.Slot5) {
-					slot1, _ := xml.Marshal(stomp1.Slot5)
-					slot2, _ := xml.Marshal(stomp2.Slot5)
+				if !elementsEqual(stomp1.child("Slot5"), stomp2.child("Slot5")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot5"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot5"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -1758,61 +1742,59 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompB2
-				stomp2 := preset2.StompB2
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompB2")
+				stomp2 := preset2.child("StompB2")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if stomp1.Stomp4 != stomp2.Stomp4 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp4 + "; was " + stomp2.Stomp4)
+				if stomp1.attr("Stomp4") != stomp2.attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp4") + "; was " + stomp2.attr("Stomp4"))
 				}
-				if stomp1.Stomp5 != stomp2.Stomp5 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp5 + "; was " + stomp2.Stomp5)
+				if stomp1.attr("Stomp5") != stomp2.attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp5") + "; was " + stomp2.attr("Stomp5"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3, stomp2.Slot3) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(stomp1.child("Slot3"), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot4, stomp2.Slot4) {
-					slot1, _ := xml.Marshal(stomp1.Slot4)
-					slot2, _ := xml.Marshal(stomp2.Slot4)
+				if !elementsEqual(stomp1.child("Slot4"), stomp2.child("Slot4")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot4"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot4"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot5, stomp2.Slot5) {
-					slot1, _ := xml.Marshal(stomp1.Slot5)
-					slot2, _ := xml.Marshal(stomp2.Slot5)
+				if !elementsEqual(stomp1.child("Slot5"), stomp2.child("Slot5")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot5"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot5"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -1832,61 +1814,59 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompB3
-				stomp2 := preset2.StompB3
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompB3")
+				stomp2 := preset2.child("StompB3")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if stomp1.Stomp4 != stomp2.Stomp4 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp4 + "; was " + stomp2.Stomp4)
+				if stomp1.attr("Stomp4") != stomp2.attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp4") + "; was " + stomp2.attr("Stomp4"))
 				}
-				if stomp1.Stomp5 != stomp2.Stomp5 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp5 + "; was " + stomp2.Stomp5)
+				if stomp1.attr("Stomp5") != stomp2.attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp5") + "; was " + stomp2.attr("Stomp5"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3, stomp2.Slot3) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(stomp1.child("Slot3"), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot4, stomp2.Slot4) {
-					slot1, _ := xml.Marshal(stomp1.Slot4)
-					slot2, _ := xml.Marshal(stomp2.Slot4)
+				if !elementsEqual(stomp1.child("Slot4"), stomp2.child("Slot4")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot4"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot4"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot5, stomp2.Slot5) {
-					slot1, _ := xml.Marshal(stomp1.Slot5)
-					slot2, _ := xml.Marshal(stomp2.Slot5)
+				if !elementsEqual(stomp1.child("Slot5"), stomp2.child("Slot5")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot5"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot5"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -1906,37 +1886,35 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompStereo
-				stomp2 := preset2.StompStereo
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompStereo")
+				stomp2 := preset2.child("StompStereo")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -1956,45 +1934,43 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.LoopFxA
-				stomp2 := preset2.LoopFxA
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("LoopFxA")
+				stomp2 := preset2.child("LoopFxA")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3, stomp2.Slot3) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(stomp1.child("Slot3"), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2014,45 +1990,43 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.LoopFxB
-				stomp2 := preset2.LoopFxB
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("LoopFxB")
+				stomp2 := preset2.child("LoopFxB")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3, stomp2.Slot3) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(stomp1.child("Slot3"), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2072,45 +2046,43 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.LoopFxC
-				stomp2 := preset2.LoopFxC
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("LoopFxC")
+				stomp2 := preset2.child("LoopFxC")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3, stomp2.Slot3) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(stomp1.child("Slot3"), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2130,29 +2102,27 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.RackA
-				stomp2 := preset2.RackA
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("RackA")
+				stomp2 := preset2.child("RackA")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2172,29 +2142,27 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.RackB
-				stomp2 := preset2.RackB
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("RackB")
+				stomp2 := preset2.child("RackB")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2214,29 +2182,27 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.RackC
-				stomp2 := preset2.RackC
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("RackC")
+				stomp2 := preset2.child("RackC")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2256,29 +2222,27 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.RackDI
-				stomp2 := preset2.RackDI
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("RackDI")
+				stomp2 := preset2.child("RackDI")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2298,61 +2262,59 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.RackMaster
-				stomp2 := preset2.RackMaster
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("RackMaster")
+				stomp2 := preset2.child("RackMaster")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if stomp1.Stomp4 != stomp2.Stomp4 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp4 + "; was " + stomp2.Stomp4)
+				if stomp1.attr("Stomp4") != stomp2.attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp4") + "; was " + stomp2.attr("Stomp4"))
 				}
-				if stomp1.Stomp5 != stomp2.Stomp5 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp5 + "; was " + stomp2.Stomp5)
+				if stomp1.attr("Stomp5") != stomp2.attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp5") + "; was " + stomp2.attr("Stomp5"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3, stomp2.Slot3) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(stomp1.child("Slot3"), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot4, stomp2.Slot4) {
-					slot1, _ := xml.Marshal(stomp1.Slot4)
-					slot2, _ := xml.Marshal(stomp2.Slot4)
+				if !elementsEqual(stomp1.child("Slot4"), stomp2.child("Slot4")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot4"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot4"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot5, stomp2.Slot5) {
-					slot1, _ := xml.Marshal(stomp1.Slot5)
-					slot2, _ := xml.Marshal(stomp2.Slot5)
+				if !elementsEqual(stomp1.child("Slot5"), stomp2.child("Slot5")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot5"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot5"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2373,61 +2335,59 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA1
-				stomp2 := preset2.StompA2
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompA1")
+				stomp2 := preset2.child("StompA2")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if stomp1.Stomp4 != stomp2.Stomp4 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp4 + "; was " + stomp2.Stomp4)
+				if stomp1.attr("Stomp4") != stomp2.attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp4") + "; was " + stomp2.attr("Stomp4"))
 				}
-				if stomp1.Stomp5 != stomp2.Stomp5 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp5 + "; was " + stomp2.Stomp5)
+				if stomp1.attr("Stomp5") != stomp2.attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp5") + "; was " + stomp2.attr("Stomp5"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3, stomp2.Slot3) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(stomp1.child("Slot3"), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot4, stomp2.Slot4) {
-					slot1, _ := xml.Marshal(stomp1.Slot4)
-					slot2, _ := xml.Marshal(stomp2.Slot4)
+				if !elementsEqual(stomp1.child("Slot4"), stomp2.child("Slot4")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot4"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot4"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot5, stomp2.Slot5) {
-					slot1, _ := xml.Marshal(stomp1.Slot5)
-					slot2, _ := xml.Marshal(stomp2.Slot5)
+				if !elementsEqual(stomp1.child("Slot5"), stomp2.child("Slot5")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot5"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot5"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2448,37 +2408,35 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA1
-				stomp2 := preset2.StompStereo
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompA1")
+				stomp2 := preset2.child("StompStereo")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2499,66 +2457,74 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompStereo
-				stomp2 := preset2.StompA1
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompStereo")
+				stomp2 := preset2.child("StompA1")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp2.Stomp3 != EmptySlotGUID {
-					return errors.New("unexpected GUID; expected " + EmptySlotGUID + "; was " + stomp2.Stomp3)
+				if stomp2.attr("Stomp3") != EmptySlotGUID {
+					return errors.New("unexpected GUID; expected " + EmptySlotGUID + "; was " + stomp2.attr("Stomp3"))
 				}
-				if stomp2.Stomp4 != EmptySlotGUID {
-					return errors.New("unexpected GUID; expected " + EmptySlotGUID + "; was " + stomp2.Stomp4)
+				if stomp2.attr("Stomp4") != EmptySlotGUID {
+					return errors.New("unexpected GUID; expected " + EmptySlotGUID + "; was " + stomp2.attr("Stomp4"))
 				}
-				if stomp2.Stomp5 != EmptySlotGUID {
-					return errors.New("unexpected GUID; expected " + EmptySlotGUID + "; was " + stomp2.Stomp5)
+				if stomp2.attr("Stomp5") != EmptySlotGUID {
+					return errors.New("unexpected GUID; expected " + EmptySlotGUID + "; was " + stomp2.attr("Stomp5"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0, stomp2.Slot0) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !elementsEqual(stomp1.child("Slot0"), stomp2.child("Slot0")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1, stomp2.Slot1) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !elementsEqual(stomp1.child("Slot1"), stomp2.child("Slot1")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2, stomp2.Slot2) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !elementsEqual(stomp1.child("Slot2"), stomp2.child("Slot2")) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(emptySlot3(), stomp2.Slot3) {
-					slot1, _ := xml.Marshal(Slot3{})
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !elementsEqual(emptySlot(3), stomp2.child("Slot3")) {
+					slot1, _ := xml.Marshal(emptySlot(3))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(emptySlot4(), stomp2.Slot4) {
-					slot1, _ := xml.Marshal(Slot4{})
-					slot2, _ := xml.Marshal(stomp2.Slot4)
+				if !elementsEqual(emptySlot(4), stomp2.child("Slot4")) {
+					slot1, _ := xml.Marshal(emptySlot(4))
+					slot2, _ := xml.Marshal(stomp2.child("Slot4"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(emptySlot5(), stomp2.Slot5) {
-					slot1, _ := xml.Marshal(Slot5{})
-					slot2, _ := xml.Marshal(stomp2.Slot5)
+				if !elementsEqual(emptySlot(5), stomp2.child("Slot5")) {
+					slot1, _ := xml.Marshal(emptySlot(5))
+					slot2, _ := xml.Marshal(stomp2.child("Slot5"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
 			},
 		},
+		{
+			Name:    "Copy gear to missing preset",
+			Command: "cpg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSource"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Missing"+PresetExtension),
+				"AmpA",
+			},
+			ExpectedError: "path not found",
+		},
 		{
 			Name:    "Copy one amp to incompatible slot",
 			Command: "cpg",
@@ -2588,24 +2554,20 @@ Amplitube\SVX:
 				data2, err := ioutil.ReadFile(file2)
 				data3, err := ioutil.ReadFile(file3)
 				data4, err := ioutil.ReadFile(file4)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				var preset3 PresetXMLV5
-				var preset4 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
-				err = xml.Unmarshal(data3, &preset3)
-				err = xml.Unmarshal(data4, &preset4)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
+				preset3, err := parsePreset(data3)
+				preset4, err := parsePreset(data4)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpA.Model == preset2.AmpA.Model {
+				if preset1.child("AmpA").attr("Model") == preset2.child("AmpA").attr("Model") {
 					return errors.New("amps match; expected them to not")
 				}
-				if preset1.AmpA.Model == preset3.AmpA.Model {
+				if preset1.child("AmpA").attr("Model") == preset3.child("AmpA").attr("Model") {
 					return errors.New("amps match; expected them to not")
 				}
-				if preset1.AmpA.Model == preset4.AmpA.Model {
+				if preset1.child("AmpA").attr("Model") == preset4.child("AmpA").attr("Model") {
 					return errors.New("amps match; expected them to not")
 				}
 				return nil
@@ -2629,25 +2591,21 @@ Amplitube\SVX:
 				data2, err := ioutil.ReadFile(file2)
 				data3, err := ioutil.ReadFile(file3)
 				data4, err := ioutil.ReadFile(file4)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				var preset3 PresetXMLV5
-				var preset4 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
-				err = xml.Unmarshal(data3, &preset3)
-				err = xml.Unmarshal(data4, &preset4)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
+				preset3, err := parsePreset(data3)
+				preset4, err := parsePreset(data4)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpA.Model != preset2.AmpA.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpA.Model + "; was " + preset2.AmpA.Model)
+				if preset1.child("AmpA").attr("Model") != preset2.child("AmpA").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpA").attr("Model") + "; was " + preset2.child("AmpA").attr("Model"))
 				}
-				if preset1.AmpA.Model != preset3.AmpA.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpA.Model + "; was " + preset3.AmpA.Model)
+				if preset1.child("AmpA").attr("Model") != preset3.child("AmpA").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpA").attr("Model") + "; was " + preset3.child("AmpA").attr("Model"))
 				}
-				if preset1.AmpA.Model != preset4.AmpA.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpA.Model + "; was " + preset4.AmpA.Model)
+				if preset1.child("AmpA").attr("Model") != preset4.child("AmpA").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpA").attr("Model") + "; was " + preset4.child("AmpA").attr("Model"))
 				}
 				return nil
 			},
@@ -2665,21 +2623,19 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpA.Model != preset2.AmpA.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpA.Model + "; was " + preset2.AmpA.Model)
+				if preset1.child("AmpA").attr("Model") != preset2.child("AmpA").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpA").attr("Model") + "; was " + preset2.child("AmpA").attr("Model"))
 				}
-				if preset1.AmpB.Model != preset2.AmpB.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpB.Model + "; was " + preset2.AmpB.Model)
+				if preset1.child("AmpB").attr("Model") != preset2.child("AmpB").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpB").attr("Model") + "; was " + preset2.child("AmpB").attr("Model"))
 				}
-				if preset1.AmpC.Model != preset2.AmpC.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpC.Model + "; was " + preset2.AmpC.Model)
+				if preset1.child("AmpC").attr("Model") != preset2.child("AmpC").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpC").attr("Model") + "; was " + preset2.child("AmpC").attr("Model"))
 				}
 				return nil
 			},
@@ -2697,21 +2653,19 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.CabA.CabModel != preset2.CabA.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabA.CabModel + "; was " + preset2.CabA.CabModel)
+				if preset1.child("CabA").attr("CabModel") != preset2.child("CabA").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabA").attr("CabModel") + "; was " + preset2.child("CabA").attr("CabModel"))
 				}
-				if preset1.CabB.CabModel != preset2.CabB.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabB.CabModel + "; was " + preset2.CabB.CabModel)
+				if preset1.child("CabB").attr("CabModel") != preset2.child("CabB").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabB").attr("CabModel") + "; was " + preset2.child("CabB").attr("CabModel"))
 				}
-				if preset1.CabC.CabModel != preset2.CabC.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabC.CabModel + "; was " + preset2.CabC.CabModel)
+				if preset1.child("CabC").attr("CabModel") != preset2.child("CabC").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabC").attr("CabModel") + "; was " + preset2.child("CabC").attr("CabModel"))
 				}
 				return nil
 			},
@@ -2730,30 +2684,28 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.AmpA.Model != preset2.AmpA.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpA.Model + "; was " + preset2.AmpA.Model)
+				if preset1.child("AmpA").attr("Model") != preset2.child("AmpA").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpA").attr("Model") + "; was " + preset2.child("AmpA").attr("Model"))
 				}
-				if preset1.AmpB.Model != preset2.AmpB.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpB.Model + "; was " + preset2.AmpB.Model)
+				if preset1.child("AmpB").attr("Model") != preset2.child("AmpB").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpB").attr("Model") + "; was " + preset2.child("AmpB").attr("Model"))
 				}
-				if preset1.AmpC.Model != preset2.AmpC.Model {
-					return errors.New("amps do not match; expected " + preset1.AmpC.Model + "; was " + preset2.AmpC.Model)
+				if preset1.child("AmpC").attr("Model") != preset2.child("AmpC").attr("Model") {
+					return errors.New("amps do not match; expected " + preset1.child("AmpC").attr("Model") + "; was " + preset2.child("AmpC").attr("Model"))
 				}
-				if preset1.CabA.CabModel != preset2.CabA.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabA.CabModel + "; was " + preset2.CabA.CabModel)
+				if preset1.child("CabA").attr("CabModel") != preset2.child("CabA").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabA").attr("CabModel") + "; was " + preset2.child("CabA").attr("CabModel"))
 				}
-				if preset1.CabB.CabModel != preset2.CabB.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabB.CabModel + "; was " + preset2.CabB.CabModel)
+				if preset1.child("CabB").attr("CabModel") != preset2.child("CabB").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabB").attr("CabModel") + "; was " + preset2.child("CabB").attr("CabModel"))
 				}
-				if preset1.CabC.CabModel != preset2.CabC.CabModel {
-					return errors.New("cabs do not match; expected " + preset1.CabC.CabModel + "; was " + preset2.CabC.CabModel)
+				if preset1.child("CabC").attr("CabModel") != preset2.child("CabC").attr("CabModel") {
+					return errors.New("cabs do not match; expected " + preset1.child("CabC").attr("CabModel") + "; was " + preset2.child("CabC").attr("CabModel"))
 				}
 				return nil
 			},
@@ -2771,183 +2723,181 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "Default"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				if preset1.StompA1.Stomp0 != preset2.StompA1.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.StompA1.Stomp0 + "; was " + preset2.StompA1.Stomp0)
+				if preset1.child("StompA1").attr("Stomp0") != preset2.child("StompA1").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA1").attr("Stomp0") + "; was " + preset2.child("StompA1").attr("Stomp0"))
 				}
-				if preset1.StompA1.Stomp1 != preset2.StompA1.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.StompA1.Stomp1 + "; was " + preset2.StompA1.Stomp1)
+				if preset1.child("StompA1").attr("Stomp1") != preset2.child("StompA1").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA1").attr("Stomp1") + "; was " + preset2.child("StompA1").attr("Stomp1"))
 				}
-				if preset1.StompA1.Stomp2 != preset2.StompA1.Stomp2 {
-					return errors.New("stomps do not match; expected " + preset1.StompA1.Stomp2 + "; was " + preset2.StompA1.Stomp2)
+				if preset1.child("StompA1").attr("Stomp2") != preset2.child("StompA1").attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA1").attr("Stomp2") + "; was " + preset2.child("StompA1").attr("Stomp2"))
 				}
-				if preset1.StompA1.Stomp3 != preset2.StompA1.Stomp3 {
-					return errors.New("stomps do not match; expected " + preset1.StompA1.Stomp3 + "; was " + preset2.StompA1.Stomp3)
+				if preset1.child("StompA1").attr("Stomp3") != preset2.child("StompA1").attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA1").attr("Stomp3") + "; was " + preset2.child("StompA1").attr("Stomp3"))
 				}
-				if preset1.StompA1.Stomp4 != preset2.StompA1.Stomp4 {
-					return errors.New("stomps do not match; expected " + preset1.StompA1.Stomp4 + "; was " + preset2.StompA1.Stomp4)
+				if preset1.child("StompA1").attr("Stomp4") != preset2.child("StompA1").attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA1").attr("Stomp4") + "; was " + preset2.child("StompA1").attr("Stomp4"))
 				}
-				if preset1.StompA1.Stomp5 != preset2.StompA1.Stomp5 {
-					return errors.New("stomps do not match; expected " + preset1.StompA1.Stomp5 + "; was " + preset2.StompA1.Stomp5)
+				if preset1.child("StompA1").attr("Stomp5") != preset2.child("StompA1").attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA1").attr("Stomp5") + "; was " + preset2.child("StompA1").attr("Stomp5"))
 				}
-				if preset1.StompA2.Stomp0 != preset2.StompA2.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.StompA2.Stomp0 + "; was " + preset2.StompA2.Stomp0)
+				if preset1.child("StompA2").attr("Stomp0") != preset2.child("StompA2").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA2").attr("Stomp0") + "; was " + preset2.child("StompA2").attr("Stomp0"))
 				}
-				if preset1.StompA2.Stomp1 != preset2.StompA2.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.StompA2.Stomp1 + "; was " + preset2.StompA2.Stomp1)
+				if preset1.child("StompA2").attr("Stomp1") != preset2.child("StompA2").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA2").attr("Stomp1") + "; was " + preset2.child("StompA2").attr("Stomp1"))
 				}
-				if preset1.StompA2.Stomp2 != preset2.StompA2.Stomp2 {
-					return errors.New("stomps do not match; expected " + preset1.StompA2.Stomp2 + "; was " + preset2.StompA2.Stomp2)
+				if preset1.child("StompA2").attr("Stomp2") != preset2.child("StompA2").attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA2").attr("Stomp2") + "; was " + preset2.child("StompA2").attr("Stomp2"))
 				}
-				if preset1.StompA2.Stomp3 != preset2.StompA2.Stomp3 {
-					return errors.New("stomps do not match; expected " + preset1.StompA2.Stomp3 + "; was " + preset2.StompA2.Stomp3)
+				if preset1.child("StompA2").attr("Stomp3") != preset2.child("StompA2").attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA2").attr("Stomp3") + "; was " + preset2.child("StompA2").attr("Stomp3"))
 				}
-				if preset1.StompA2.Stomp4 != preset2.StompA2.Stomp4 {
-					return errors.New("stomps do not match; expected " + preset1.StompA2.Stomp4 + "; was " + preset2.StompA2.Stomp4)
+				if preset1.child("StompA2").attr("Stomp4") != preset2.child("StompA2").attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA2").attr("Stomp4") + "; was " + preset2.child("StompA2").attr("Stomp4"))
 				}
-				if preset1.StompA2.Stomp5 != preset2.StompA2.Stomp5 {
-					return errors.New("stomps do not match; expected " + preset1.StompA2.Stomp5 + "; was " + preset2.StompA2.Stomp5)
+				if preset1.child("StompA2").attr("Stomp5") != preset2.child("StompA2").attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompA2").attr("Stomp5") + "; was " + preset2.child("StompA2").attr("Stomp5"))
 				}
-				if preset1.StompStereo.Stomp0 != preset2.StompStereo.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.StompStereo.Stomp0 + "; was " + preset2.StompStereo.Stomp0)
+				if preset1.child("StompStereo").attr("Stomp0") != preset2.child("StompStereo").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompStereo").attr("Stomp0") + "; was " + preset2.child("StompStereo").attr("Stomp0"))
 				}
-				if preset1.StompStereo.Stomp1 != preset2.StompStereo.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.StompStereo.Stomp1 + "; was " + preset2.StompStereo.Stomp1)
+				if preset1.child("StompStereo").attr("Stomp1") != preset2.child("StompStereo").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompStereo").attr("Stomp1") + "; was " + preset2.child("StompStereo").attr("Stomp1"))
 				}
-				if preset1.StompStereo.Stomp2 != preset2.StompStereo.Stomp2 {
-					return errors.New("stomps do not match; expected " + preset1.StompStereo.Stomp2 + "; was " + preset2.StompStereo.Stomp2)
+				if preset1.child("StompStereo").attr("Stomp2") != preset2.child("StompStereo").attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompStereo").attr("Stomp2") + "; was " + preset2.child("StompStereo").attr("Stomp2"))
 				}
-				if preset1.StompB1.Stomp0 != preset2.StompB1.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.StompB1.Stomp0 + "; was " + preset2.StompB1.Stomp0)
+				if preset1.child("StompB1").attr("Stomp0") != preset2.child("StompB1").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB1").attr("Stomp0") + "; was " + preset2.child("StompB1").attr("Stomp0"))
 				}
-				if preset1.StompB1.Stomp1 != preset2.StompB1.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.StompB1.Stomp1 + "; was " + preset2.StompB1.Stomp1)
+				if preset1.child("StompB1").attr("Stomp1") != preset2.child("StompB1").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB1").attr("Stomp1") + "; was " + preset2.child("StompB1").attr("Stomp1"))
 				}
-				if preset1.StompB1.Stomp2 != preset2.StompB1.Stomp2 {
-					return errors.New("stomps do not match; expected " + preset1.StompB1.Stomp2 + "; was " + preset2.StompB1.Stomp2)
+				if preset1.child("StompB1").attr("Stomp2") != preset2.child("StompB1").attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB1").attr("Stomp2") + "; was " + preset2.child("StompB1").attr("Stomp2"))
 				}
-				if preset1.StompB1.Stomp3 != preset2.StompB1.Stomp3 {
-					return errors.New("stomps do not match; expected " + preset1.StompB1.Stomp3 + "; was " + preset2.StompB1.Stomp3)
+				if preset1.child("StompB1").attr("Stomp3") != preset2.child("StompB1").attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB1").attr("Stomp3") + "; was " + preset2.child("StompB1").attr("Stomp3"))
 				}
-				if preset1.StompB1.Stomp4 != preset2.StompB1.Stomp4 {
-					return errors.New("stomps do not match; expected " + preset1.StompB1.Stomp4 + "; was " + preset2.StompB1.Stomp4)
+				if preset1.child("StompB1").attr("Stomp4") != preset2.child("StompB1").attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB1").attr("Stomp4") + "; was " + preset2.child("StompB1").attr("Stomp4"))
 				}
-				if preset1.StompB1.Stomp5 != preset2.StompB1.Stomp5 {
-					return errors.New("stomps do not match; expected " + preset1.StompB1.Stomp5 + "; was " + preset2.StompB1.Stomp5)
+				if preset1.child("StompB1").attr("Stomp5") != preset2.child("StompB1").attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB1").attr("Stomp5") + "; was " + preset2.child("StompB1").attr("Stomp5"))
 				}
-				if preset1.StompB2.Stomp0 != preset2.StompB2.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.StompB2.Stomp0 + "; was " + preset2.StompB2.Stomp0)
+				if preset1.child("StompB2").attr("Stomp0") != preset2.child("StompB2").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB2").attr("Stomp0") + "; was " + preset2.child("StompB2").attr("Stomp0"))
 				}
-				if preset1.StompB2.Stomp1 != preset2.StompB2.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.StompB2.Stomp1 + "; was " + preset2.StompB2.Stomp1)
+				if preset1.child("StompB2").attr("Stomp1") != preset2.child("StompB2").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB2").attr("Stomp1") + "; was " + preset2.child("StompB2").attr("Stomp1"))
 				}
-				if preset1.StompB2.Stomp2 != preset2.StompB2.Stomp2 {
-					return errors.New("stomps do not match; expected " + preset1.StompB2.Stomp2 + "; was " + preset2.StompB2.Stomp2)
+				if preset1.child("StompB2").attr("Stomp2") != preset2.child("StompB2").attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB2").attr("Stomp2") + "; was " + preset2.child("StompB2").attr("Stomp2"))
 				}
-				if preset1.StompB2.Stomp3 != preset2.StompB2.Stomp3 {
-					return errors.New("stomps do not match; expected " + preset1.StompB2.Stomp3 + "; was " + preset2.StompB2.Stomp3)
+				if preset1.child("StompB2").attr("Stomp3") != preset2.child("StompB2").attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB2").attr("Stomp3") + "; was " + preset2.child("StompB2").attr("Stomp3"))
 				}
-				if preset1.StompB2.Stomp4 != preset2.StompB2.Stomp4 {
-					return errors.New("stomps do not match; expected " + preset1.StompB2.Stomp4 + "; was " + preset2.StompB2.Stomp4)
+				if preset1.child("StompB2").attr("Stomp4") != preset2.child("StompB2").attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB2").attr("Stomp4") + "; was " + preset2.child("StompB2").attr("Stomp4"))
 				}
-				if preset1.StompB2.Stomp5 != preset2.StompB2.Stomp5 {
-					return errors.New("stomps do not match; expected " + preset1.StompB2.Stomp5 + "; was " + preset2.StompB2.Stomp5)
+				if preset1.child("StompB2").attr("Stomp5") != preset2.child("StompB2").attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB2").attr("Stomp5") + "; was " + preset2.child("StompB2").attr("Stomp5"))
 				}
-				if preset1.StompB3.Stomp0 != preset2.StompB3.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.StompB3.Stomp0 + "; was " + preset2.StompB3.Stomp0)
+				if preset1.child("StompB3").attr("Stomp0") != preset2.child("StompB3").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB3").attr("Stomp0") + "; was " + preset2.child("StompB3").attr("Stomp0"))
 				}
-				if preset1.StompB3.Stomp1 != preset2.StompB3.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.StompB3.Stomp1 + "; was " + preset2.StompB3.Stomp1)
+				if preset1.child("StompB3").attr("Stomp1") != preset2.child("StompB3").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB3").attr("Stomp1") + "; was " + preset2.child("StompB3").attr("Stomp1"))
 				}
-				if preset1.StompB3.Stomp2 != preset2.StompB3.Stomp2 {
-					return errors.New("stomps do not match; expected " + preset1.StompB3.Stomp2 + "; was " + preset2.StompB3.Stomp2)
+				if preset1.child("StompB3").attr("Stomp2") != preset2.child("StompB3").attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB3").attr("Stomp2") + "; was " + preset2.child("StompB3").attr("Stomp2"))
 				}
-				if preset1.StompB3.Stomp3 != preset2.StompB3.Stomp3 {
-					return errors.New("stomps do not match; expected " + preset1.StompB3.Stomp3 + "; was " + preset2.StompB3.Stomp3)
+				if preset1.child("StompB3").attr("Stomp3") != preset2.child("StompB3").attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB3").attr("Stomp3") + "; was " + preset2.child("StompB3").attr("Stomp3"))
 				}
-				if preset1.StompB3.Stomp4 != preset2.StompB3.Stomp4 {
-					return errors.New("stomps do not match; expected " + preset1.StompB3.Stomp4 + "; was " + preset2.StompB3.Stomp4)
+				if preset1.child("StompB3").attr("Stomp4") != preset2.child("StompB3").attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB3").attr("Stomp4") + "; was " + preset2.child("StompB3").attr("Stomp4"))
 				}
-				if preset1.StompB3.Stomp5 != preset2.StompB3.Stomp5 {
-					return errors.New("stomps do not match; expected " + preset1.StompB3.Stomp5 + "; was " + preset2.StompB3.Stomp5)
+				if preset1.child("StompB3").attr("Stomp5") != preset2.child("StompB3").attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + preset1.child("StompB3").attr("Stomp5") + "; was " + preset2.child("StompB3").attr("Stomp5"))
 				}
-				if preset1.LoopFxA.Stomp0 != preset2.LoopFxA.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxA.Stomp0 + "; was " + preset2.LoopFxA.Stomp0)
+				if preset1.child("LoopFxA").attr("Stomp0") != preset2.child("LoopFxA").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxA").attr("Stomp0") + "; was " + preset2.child("LoopFxA").attr("Stomp0"))
 				}
-				if preset1.LoopFxA.Stomp1 != preset2.LoopFxA.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxA.Stomp1 + "; was " + preset2.LoopFxA.Stomp1)
+				if preset1.child("LoopFxA").attr("Stomp1") != preset2.child("LoopFxA").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxA").attr("Stomp1") + "; was " + preset2.child("LoopFxA").attr("Stomp1"))
 				}
-				if preset1.LoopFxA.Stomp2 != preset2.LoopFxA.Stomp2 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxA.Stomp2 + "; was " + preset2.LoopFxA.Stomp2)
+				if preset1.child("LoopFxA").attr("Stomp2") != preset2.child("LoopFxA").attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxA").attr("Stomp2") + "; was " + preset2.child("LoopFxA").attr("Stomp2"))
 				}
-				if preset1.LoopFxA.Stomp3 != preset2.LoopFxA.Stomp3 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxA.Stomp3 + "; was " + preset2.LoopFxA.Stomp3)
+				if preset1.child("LoopFxA").attr("Stomp3") != preset2.child("LoopFxA").attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxA").attr("Stomp3") + "; was " + preset2.child("LoopFxA").attr("Stomp3"))
 				}
-				if preset1.LoopFxB.Stomp0 != preset2.LoopFxB.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxB.Stomp0 + "; was " + preset2.LoopFxB.Stomp0)
+				if preset1.child("LoopFxB").attr("Stomp0") != preset2.child("LoopFxB").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxB").attr("Stomp0") + "; was " + preset2.child("LoopFxB").attr("Stomp0"))
 				}
-				if preset1.LoopFxB.Stomp1 != preset2.LoopFxB.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxB.Stomp1 + "; was " + preset2.LoopFxB.Stomp1)
+				if preset1.child("LoopFxB").attr("Stomp1") != preset2.child("LoopFxB").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxB").attr("Stomp1") + "; was " + preset2.child("LoopFxB").attr("Stomp1"))
 				}
-				if preset1.LoopFxB.Stomp2 != preset2.LoopFxB.Stomp2 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxB.Stomp2 + "; was " + preset2.LoopFxB.Stomp2)
+				if preset1.child("LoopFxB").attr("Stomp2") != preset2.child("LoopFxB").attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxB").attr("Stomp2") + "; was " + preset2.child("LoopFxB").attr("Stomp2"))
 				}
-				if preset1.LoopFxB.Stomp3 != preset2.LoopFxB.Stomp3 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxB.Stomp3 + "; was " + preset2.LoopFxB.Stomp3)
+				if preset1.child("LoopFxB").attr("Stomp3") != preset2.child("LoopFxB").attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxB").attr("Stomp3") + "; was " + preset2.child("LoopFxB").attr("Stomp3"))
 				}
-				if preset1.LoopFxC.Stomp0 != preset2.LoopFxC.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxC.Stomp0 + "; was " + preset2.LoopFxC.Stomp0)
+				if preset1.child("LoopFxC").attr("Stomp0") != preset2.child("LoopFxC").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxC").attr("Stomp0") + "; was " + preset2.child("LoopFxC").attr("Stomp0"))
 				}
-				if preset1.LoopFxC.Stomp1 != preset2.LoopFxC.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxC.Stomp1 + "; was " + preset2.LoopFxC.Stomp1)
+				if preset1.child("LoopFxC").attr("Stomp1") != preset2.child("LoopFxC").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxC").attr("Stomp1") + "; was " + preset2.child("LoopFxC").attr("Stomp1"))
 				}
-				if preset1.LoopFxC.Stomp2 != preset2.LoopFxC.Stomp2 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxC.Stomp2 + "; was " + preset2.LoopFxC.Stomp2)
+				if preset1.child("LoopFxC").attr("Stomp2") != preset2.child("LoopFxC").attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxC").attr("Stomp2") + "; was " + preset2.child("LoopFxC").attr("Stomp2"))
 				}
-				if preset1.LoopFxC.Stomp3 != preset2.LoopFxC.Stomp3 {
-					return errors.New("stomps do not match; expected " + preset1.LoopFxC.Stomp3 + "; was " + preset2.LoopFxC.Stomp3)
+				if preset1.child("LoopFxC").attr("Stomp3") != preset2.child("LoopFxC").attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + preset1.child("LoopFxC").attr("Stomp3") + "; was " + preset2.child("LoopFxC").attr("Stomp3"))
 				}
-				if preset1.RackA.Stomp0 != preset2.RackA.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.RackA.Stomp0 + "; was " + preset2.RackA.Stomp0)
+				if preset1.child("RackA").attr("Stomp0") != preset2.child("RackA").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackA").attr("Stomp0") + "; was " + preset2.child("RackA").attr("Stomp0"))
 				}
-				if preset1.RackA.Stomp1 != preset2.RackA.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.RackA.Stomp1 + "; was " + preset2.RackA.Stomp1)
+				if preset1.child("RackA").attr("Stomp1") != preset2.child("RackA").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackA").attr("Stomp1") + "; was " + preset2.child("RackA").attr("Stomp1"))
 				}
-				if preset1.RackB.Stomp0 != preset2.RackB.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.RackB.Stomp0 + "; was " + preset2.RackB.Stomp0)
+				if preset1.child("RackB").attr("Stomp0") != preset2.child("RackB").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackB").attr("Stomp0") + "; was " + preset2.child("RackB").attr("Stomp0"))
 				}
-				if preset1.RackB.Stomp1 != preset2.RackB.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.RackB.Stomp1 + "; was " + preset2.RackB.Stomp1)
+				if preset1.child("RackB").attr("Stomp1") != preset2.child("RackB").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackB").attr("Stomp1") + "; was " + preset2.child("RackB").attr("Stomp1"))
 				}
-				if preset1.RackC.Stomp0 != preset2.RackC.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.RackC.Stomp0 + "; was " + preset2.RackC.Stomp0)
+				if preset1.child("RackC").attr("Stomp0") != preset2.child("RackC").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackC").attr("Stomp0") + "; was " + preset2.child("RackC").attr("Stomp0"))
 				}
-				if preset1.RackC.Stomp1 != preset2.RackC.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.RackC.Stomp1 + "; was " + preset2.RackC.Stomp1)
+				if preset1.child("RackC").attr("Stomp1") != preset2.child("RackC").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackC").attr("Stomp1") + "; was " + preset2.child("RackC").attr("Stomp1"))
 				}
-				if preset1.RackMaster.Stomp0 != preset2.RackMaster.Stomp0 {
-					return errors.New("stomps do not match; expected " + preset1.RackMaster.Stomp0 + "; was " + preset2.RackMaster.Stomp0)
+				if preset1.child("RackMaster").attr("Stomp0") != preset2.child("RackMaster").attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackMaster").attr("Stomp0") + "; was " + preset2.child("RackMaster").attr("Stomp0"))
 				}
-				if preset1.RackMaster.Stomp1 != preset2.RackMaster.Stomp1 {
-					return errors.New("stomps do not match; expected " + preset1.RackMaster.Stomp1 + "; was " + preset2.RackMaster.Stomp1)
+				if preset1.child("RackMaster").attr("Stomp1") != preset2.child("RackMaster").attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackMaster").attr("Stomp1") + "; was " + preset2.child("RackMaster").attr("Stomp1"))
 				}
-				if preset1.RackMaster.Stomp2 != preset2.RackMaster.Stomp2 {
-					return errors.New("stomps do not match; expected " + preset1.RackMaster.Stomp2 + "; was " + preset2.RackMaster.Stomp2)
+				if preset1.child("RackMaster").attr("Stomp2") != preset2.child("RackMaster").attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackMaster").attr("Stomp2") + "; was " + preset2.child("RackMaster").attr("Stomp2"))
 				}
-				if preset1.RackMaster.Stomp3 != preset2.RackMaster.Stomp3 {
-					return errors.New("stomps do not match; expected " + preset1.RackMaster.Stomp3 + "; was " + preset2.RackMaster.Stomp3)
+				if preset1.child("RackMaster").attr("Stomp3") != preset2.child("RackMaster").attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackMaster").attr("Stomp3") + "; was " + preset2.child("RackMaster").attr("Stomp3"))
 				}
-				if preset1.RackMaster.Stomp4 != preset2.RackMaster.Stomp4 {
-					return errors.New("stomps do not match; expected " + preset1.RackMaster.Stomp4 + "; was " + preset2.RackMaster.Stomp4)
+				if preset1.child("RackMaster").attr("Stomp4") != preset2.child("RackMaster").attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackMaster").attr("Stomp4") + "; was " + preset2.child("RackMaster").attr("Stomp4"))
 				}
-				if preset1.RackMaster.Stomp5 != preset2.RackMaster.Stomp5 {
-					return errors.New("stomps do not match; expected " + preset1.RackMaster.Stomp5 + "; was " + preset2.RackMaster.Stomp5)
+				if preset1.child("RackMaster").attr("Stomp5") != preset2.child("RackMaster").attr("Stomp5") {
+					return errors.New("stomps do not match; expected " + preset1.child("RackMaster").attr("Stomp5") + "; was " + preset2.child("RackMaster").attr("Stomp5"))
 				}
 				return nil
 			},
@@ -2965,21 +2915,19 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearEmpty"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA1
-				stomp2 := preset2.StompA1
-				if stomp1.Stomp0 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp1)
+				stomp1 := preset1.child("StompA1")
+				stomp2 := preset2.child("StompA1")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0.Attrs, stomp2.Slot1.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !reflect.DeepEqual(stomp1.child("Slot0").Attrs, stomp2.child("Slot1").Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -2999,21 +2947,19 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearEmpty"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA2
-				stomp2 := preset2.StompA1
-				if stomp1.Stomp0 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp1)
+				stomp1 := preset1.child("StompA2")
+				stomp2 := preset2.child("StompA1")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0.Attrs, stomp2.Slot1.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !reflect.DeepEqual(stomp1.child("Slot0").Attrs, stomp2.child("Slot1").Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -3033,29 +2979,27 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearEmpty"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompB1
-				stomp2 := preset2.StompStereo
-				if stomp1.Stomp0 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp1)
+				stomp1 := preset1.child("StompB1")
+				stomp2 := preset2.child("StompStereo")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1.Stomp1 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp1 + "; was " + stomp2.Stomp2)
+				if stomp1.attr("Stomp1") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp1") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0.Attrs, stomp2.Slot1.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !reflect.DeepEqual(stomp1.child("Slot0").Attrs, stomp2.child("Slot1").Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1.Attrs, stomp2.Slot2.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !reflect.DeepEqual(stomp1.child("Slot1").Attrs, stomp2.child("Slot2").Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -3076,24 +3020,22 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearEmpty"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA2
-				stomp2 := preset2.StompA1
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompA2")
+				stomp2 := preset2.child("StompA1")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp2.Stomp1 != "a1000000-0000-0000-0000-000000000000" {
-					return errors.New("stomps do not match; expected a1000000-0000-0000-0000-000000000000; was " + stomp2.Stomp1)
+				if stomp2.attr("Stomp1") != "a1000000-0000-0000-0000-000000000000" {
+					return errors.New("stomps do not match; expected a1000000-0000-0000-0000-000000000000; was " + stomp2.attr("Stomp1"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0.Attrs, stomp2.Slot0.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !reflect.DeepEqual(stomp1.child("Slot0").Attrs, stomp2.child("Slot0").Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -3113,54 +3055,52 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource2"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA2
-				stomp1b := preset1.StompB1
-				stomp2 := preset2.StompB1
-				if stomp1.Stomp0 != stomp2.Stomp4 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp4)
+				stomp1 := preset1.child("StompA2")
+				stomp1b := preset1.child("StompB1")
+				stomp2 := preset2.child("StompB1")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp4"))
 				}
-				if stomp1b.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1b.Stomp0 + "; was " + stomp2.Stomp0)
+				if stomp1b.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1b.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1b.Stomp1 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1b.Stomp1 + "; was " + stomp2.Stomp1)
+				if stomp1b.attr("Stomp1") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1b.attr("Stomp1") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1b.Stomp2 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1b.Stomp2 + "; was " + stomp2.Stomp2)
+				if stomp1b.attr("Stomp2") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1b.attr("Stomp2") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1b.Stomp3 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1b.Stomp3 + "; was " + stomp2.Stomp3)
+				if stomp1b.attr("Stomp3") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1b.attr("Stomp3") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0.Attrs, stomp2.Slot4.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot4)
+				if !reflect.DeepEqual(stomp1.child("Slot0").Attrs, stomp2.child("Slot4").Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot4"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1b.Slot0.Attrs, stomp2.Slot0.Attrs) {
-					slot1, _ := xml.Marshal(stomp1b.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !reflect.DeepEqual(stomp1b.child("Slot0").Attrs, stomp2.child("Slot0").Attrs) {
+					slot1, _ := xml.Marshal(stomp1b.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1b.Slot1.Attrs, stomp2.Slot1.Attrs) {
-					slot1, _ := xml.Marshal(stomp1b.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !reflect.DeepEqual(stomp1b.child("Slot1").Attrs, stomp2.child("Slot1").Attrs) {
+					slot1, _ := xml.Marshal(stomp1b.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1b.Slot2.Attrs, stomp2.Slot2.Attrs) {
-					slot1, _ := xml.Marshal(stomp1b.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !reflect.DeepEqual(stomp1b.child("Slot2").Attrs, stomp2.child("Slot2").Attrs) {
+					slot1, _ := xml.Marshal(stomp1b.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1b.Slot3.Attrs, stomp2.Slot3.Attrs) {
-					slot1, _ := xml.Marshal(stomp1b.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !reflect.DeepEqual(stomp1b.child("Slot3").Attrs, stomp2.child("Slot3").Attrs) {
+					slot1, _ := xml.Marshal(stomp1b.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -3181,54 +3121,52 @@ Amplitube\SVX:
 				file2 := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource2"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
 				data2, err := ioutil.ReadFile(file2)
-				var preset1 PresetXMLV5
-				var preset2 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
-				err = xml.Unmarshal(data2, &preset2)
+				preset1, err := parsePreset(data1)
+				preset2, err := parsePreset(data2)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA2
-				stomp1b := preset1.StompB1
-				stomp2 := preset2.StompB1
-				if stomp1.Stomp0 != stomp2.Stomp0 {
-					return errors.New("stomps do not match; expected " + stomp1.Stomp0 + "; was " + stomp2.Stomp0)
+				stomp1 := preset1.child("StompA2")
+				stomp1b := preset1.child("StompB1")
+				stomp2 := preset2.child("StompB1")
+				if stomp1.attr("Stomp0") != stomp2.attr("Stomp0") {
+					return errors.New("stomps do not match; expected " + stomp1.attr("Stomp0") + "; was " + stomp2.attr("Stomp0"))
 				}
-				if stomp1b.Stomp0 != stomp2.Stomp1 {
-					return errors.New("stomps do not match; expected " + stomp1b.Stomp0 + "; was " + stomp2.Stomp1)
+				if stomp1b.attr("Stomp0") != stomp2.attr("Stomp1") {
+					return errors.New("stomps do not match; expected " + stomp1b.attr("Stomp0") + "; was " + stomp2.attr("Stomp1"))
 				}
-				if stomp1b.Stomp1 != stomp2.Stomp2 {
-					return errors.New("stomps do not match; expected " + stomp1b.Stomp1 + "; was " + stomp2.Stomp2)
+				if stomp1b.attr("Stomp1") != stomp2.attr("Stomp2") {
+					return errors.New("stomps do not match; expected " + stomp1b.attr("Stomp1") + "; was " + stomp2.attr("Stomp2"))
 				}
-				if stomp1b.Stomp2 != stomp2.Stomp3 {
-					return errors.New("stomps do not match; expected " + stomp1b.Stomp2 + "; was " + stomp2.Stomp3)
+				if stomp1b.attr("Stomp2") != stomp2.attr("Stomp3") {
+					return errors.New("stomps do not match; expected " + stomp1b.attr("Stomp2") + "; was " + stomp2.attr("Stomp3"))
 				}
-				if stomp1b.Stomp3 != stomp2.Stomp4 {
-					return errors.New("stomps do not match; expected " + stomp1b.Stomp3 + "; was " + stomp2.Stomp4)
+				if stomp1b.attr("Stomp3") != stomp2.attr("Stomp4") {
+					return errors.New("stomps do not match; expected " + stomp1b.attr("Stomp3") + "; was " + stomp2.attr("Stomp4"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0.Attrs, stomp2.Slot0.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot0)
+				if !reflect.DeepEqual(stomp1.child("Slot0").Attrs, stomp2.child("Slot0").Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot0"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1b.Slot0.Attrs, stomp2.Slot1.Attrs) {
-					slot1, _ := xml.Marshal(stomp1b.Slot0)
-					slot2, _ := xml.Marshal(stomp2.Slot1)
+				if !reflect.DeepEqual(stomp1b.child("Slot0").Attrs, stomp2.child("Slot1").Attrs) {
+					slot1, _ := xml.Marshal(stomp1b.child("Slot0"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot1"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1b.Slot1.Attrs, stomp2.Slot2.Attrs) {
-					slot1, _ := xml.Marshal(stomp1b.Slot1)
-					slot2, _ := xml.Marshal(stomp2.Slot2)
+				if !reflect.DeepEqual(stomp1b.child("Slot1").Attrs, stomp2.child("Slot2").Attrs) {
+					slot1, _ := xml.Marshal(stomp1b.child("Slot1"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot2"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1b.Slot2.Attrs, stomp2.Slot3.Attrs) {
-					slot1, _ := xml.Marshal(stomp1b.Slot2)
-					slot2, _ := xml.Marshal(stomp2.Slot3)
+				if !reflect.DeepEqual(stomp1b.child("Slot2").Attrs, stomp2.child("Slot3").Attrs) {
+					slot1, _ := xml.Marshal(stomp1b.child("Slot2"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot3"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1b.Slot3.Attrs, stomp2.Slot4.Attrs) {
-					slot1, _ := xml.Marshal(stomp1b.Slot3)
-					slot2, _ := xml.Marshal(stomp2.Slot4)
+				if !reflect.DeepEqual(stomp1b.child("Slot3").Attrs, stomp2.child("Slot4").Attrs) {
+					slot1, _ := xml.Marshal(stomp1b.child("Slot3"))
+					slot2, _ := xml.Marshal(stomp2.child("Slot4"))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -3245,58 +3183,57 @@ Amplitube\SVX:
 			CustomAssertion: func(workingDir string) error {
 				file1 := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
-				var preset1 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
+				preset1, err := parsePreset(data1)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA1
-				if stomp1.Stomp0 != EmptySlotGUID {
-					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.Stomp0)
+				stomp1 := preset1.child("StompA1")
+				if stomp1.attr("Stomp0") != EmptySlotGUID {
+					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 != EmptySlotGUID {
-					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.Stomp1)
+				if stomp1.attr("Stomp1") != EmptySlotGUID {
+					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.attr("Stomp1"))
 				}
-				if stomp1.Stomp2 != EmptySlotGUID {
-					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.Stomp2)
+				if stomp1.attr("Stomp2") != EmptySlotGUID {
+					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.attr("Stomp2"))
 				}
-				if stomp1.Stomp3 != EmptySlotGUID {
-					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.Stomp3)
+				if stomp1.attr("Stomp3") != EmptySlotGUID {
+					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.attr("Stomp3"))
 				}
-				if stomp1.Stomp4 != EmptySlotGUID {
-					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.Stomp4)
+				if stomp1.attr("Stomp4") != EmptySlotGUID {
+					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.attr("Stomp4"))
 				}
-				if stomp1.Stomp5 != EmptySlotGUID {
-					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.Stomp5)
+				if stomp1.attr("Stomp5") != EmptySlotGUID {
+					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.attr("Stomp5"))
 				}
-				if !reflect.DeepEqual(stomp1.Slot0.Attrs, Slot0{}.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(Slot0{})
+				if !reflect.DeepEqual(stomp1.child("Slot0").Attrs, emptySlot(0).Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(emptySlot(0))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot1.Attrs, Slot1{}.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot1)
-					slot2, _ := xml.Marshal(Slot1{})
+				if !reflect.DeepEqual(stomp1.child("Slot1").Attrs, emptySlot(1).Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot1"))
+					slot2, _ := xml.Marshal(emptySlot(1))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot2.Attrs, Slot2{}.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot2)
-					slot2, _ := xml.Marshal(Slot2{})
+				if !reflect.DeepEqual(stomp1.child("Slot2").Attrs, emptySlot(2).Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot2"))
+					slot2, _ := xml.Marshal(emptySlot(2))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot3.Attrs, Slot3{}.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot3)
-					slot2, _ := xml.Marshal(Slot3{})
+				if !reflect.DeepEqual(stomp1.child("Slot3").Attrs, emptySlot(3).Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot3"))
+					slot2, _ := xml.Marshal(emptySlot(3))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot4.Attrs, Slot4{}.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot4)
-					slot2, _ := xml.Marshal(Slot4{})
+				if !reflect.DeepEqual(stomp1.child("Slot4").Attrs, emptySlot(4).Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot4"))
+					slot2, _ := xml.Marshal(emptySlot(4))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if !reflect.DeepEqual(stomp1.Slot5.Attrs, Slot5{}.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot5)
-					slot2, _ := xml.Marshal(Slot5{})
+				if !reflect.DeepEqual(stomp1.child("Slot5").Attrs, emptySlot(5).Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot5"))
+					slot2, _ := xml.Marshal(emptySlot(5))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
 				return nil
@@ -3313,48 +3250,47 @@ Amplitube\SVX:
 			CustomAssertion: func(workingDir string) error {
 				file1 := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSource"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
-				var preset1 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
+				preset1, err := parsePreset(data1)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA1
-				if stomp1.Stomp0 != EmptySlotGUID {
-					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.Stomp0)
+				stomp1 := preset1.child("StompA1")
+				if stomp1.attr("Stomp0") != EmptySlotGUID {
+					return errors.New("stomps do not match; expected " + EmptySlotGUID + "; was " + stomp1.attr("Stomp0"))
 				}
-				if stomp1.Stomp1 == EmptySlotGUID {
+				if stomp1.attr("Stomp1") == EmptySlotGUID {
 					return errors.New("stomps do not match; expected to not be empty")
 				}
-				if stomp1.Stomp2 == EmptySlotGUID {
+				if stomp1.attr("Stomp2") == EmptySlotGUID {
 					return errors.New("stomps do not match; expected to not be empty")
 				}
-				if stomp1.Stomp3 == EmptySlotGUID {
+				if stomp1.attr("Stomp3") == EmptySlotGUID {
 					return errors.New("stomps do not match; expected to not be empty")
 				}
-				if stomp1.Stomp4 == EmptySlotGUID {
+				if stomp1.attr("Stomp4") == EmptySlotGUID {
 					return errors.New("stomps do not match; expected to not be empty")
 				}
-				if stomp1.Stomp5 == EmptySlotGUID {
+				if stomp1.attr("Stomp5") == EmptySlotGUID {
 					return errors.New("stomps do not match; expected to not be empty")
 				}
-				if !reflect.DeepEqual(stomp1.Slot0.Attrs, Slot0{}.Attrs) {
-					slot1, _ := xml.Marshal(stomp1.Slot0)
-					slot2, _ := xml.Marshal(Slot0{})
+				if !reflect.DeepEqual(stomp1.child("Slot0").Attrs, emptySlot(0).Attrs) {
+					slot1, _ := xml.Marshal(stomp1.child("Slot0"))
+					slot2, _ := xml.Marshal(emptySlot(0))
 					return errors.New("stomps do not match; expected " + string(selfClose(slot1)) + "; was " + string(selfClose(slot2)))
 				}
-				if reflect.DeepEqual(stomp1.Slot1.Attrs, Slot1{}.Attrs) {
+				if reflect.DeepEqual(stomp1.child("Slot1").Attrs, emptySlot(1).Attrs) {
 					return errors.New("stomps do not match; expected to not be empty")
 				}
-				if reflect.DeepEqual(stomp1.Slot2.Attrs, Slot2{}.Attrs) {
+				if reflect.DeepEqual(stomp1.child("Slot2").Attrs, emptySlot(2).Attrs) {
 					return errors.New("stomps do not match; expected to not be empty")
 				}
-				if reflect.DeepEqual(stomp1.Slot3.Attrs, Slot3{}.Attrs) {
+				if reflect.DeepEqual(stomp1.child("Slot3").Attrs, emptySlot(3).Attrs) {
 					return errors.New("stomps do not match; expected to not be empty")
 				}
-				if reflect.DeepEqual(stomp1.Slot4.Attrs, Slot4{}.Attrs) {
+				if reflect.DeepEqual(stomp1.child("Slot4").Attrs, emptySlot(4).Attrs) {
 					return errors.New("stomps do not match; expected to not be empty")
 				}
-				if reflect.DeepEqual(stomp1.Slot5.Attrs, Slot5{}.Attrs) {
+				if reflect.DeepEqual(stomp1.child("Slot5").Attrs, emptySlot(5).Attrs) {
 					return errors.New("stomps do not match; expected to not be empty")
 				}
 				return nil
@@ -3370,13 +3306,12 @@ Amplitube\SVX:
 			CustomAssertion: func(workingDir string) error {
 				file1 := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource"+PresetExtension)
 				data1, err := ioutil.ReadFile(file1)
-				var preset1 PresetXMLV5
-				err = xml.Unmarshal(data1, &preset1)
+				preset1, err := parsePreset(data1)
 				if err != nil {
 					return err
 				}
-				stomp1 := preset1.StompA1
-				for _, attr := range stomp1.Slot0.Attrs {
+				stomp1 := preset1.child("StompA1")
+				for _, attr := range stomp1.child("Slot0").Attrs {
 					if attr.Name.Local == "Setting_1" && attr.Value != "1" {
 						return errors.New("slot setting not updated; expected 1; actual " + attr.Value)
 					}
@@ -3544,324 +3479,2986 @@ Amplitube\SVX:
 				filepath.Join(TestDataRoot+"[1]", PresetsFolder, "Amps2", "Amplitube", "SVX", "SVX-4B"+PresetExtension),
 			},
 		},
-		// TODO: remove orphans and add missing db records on reindex
-	} {
-		t.Run(tc.Name, func(t *testing.T) {
-
-			workDirCount := tc.WorkDirCount
-
-			if workDirCount == 0 {
-				workDirCount = 1
-			}
-
-			var workingDirs []string
-
-			for i := 0; i < workDirCount; i++ {
-				workingDir := setupData()
-				defer cleanUpData(workingDir)
-				workingDirs = append(workingDirs, workingDir)
-			}
-
-			if tc.CustomSetup != nil {
-				tc.CustomSetup(workingDirs)
-			}
-
-			var filteredArgs []string
-
-			for _, v := range tc.Args {
-				filteredArgs = append(filteredArgs, rehomePath(v, workingDirs))
-			}
-
-			out = bytes.NewBuffer(nil)
-			err := ExecuteCommand(tc.Command, filteredArgs)
-
-			if tc.Verbose {
-				t.Logf("%s", out.(*bytes.Buffer).String())
-			}
-
-			if err != nil && !strings.Contains(err.Error(), tc.ExpectedError) {
-				t.Fatal(err)
-			}
-
-			if err == nil && tc.ExpectedError != "" {
-				t.Errorf("Expected error '%s'; there was none", tc.ExpectedError)
-			}
-
-			if err != nil && tc.ExpectedError == "" {
-				t.Errorf("Error was '%s'; no error was expected", err)
-			}
-
-			if !strings.Contains(out.(*bytes.Buffer).String(), tc.Expected) {
-				t.Errorf("wanted '%s'; was '%s'", tc.Expected, out.(*bytes.Buffer).String())
-			}
-
-			for _, path := range tc.ExpectExists {
-				path = rehomePath(path, workingDirs)
-				if info, _ := os.Stat(path); info == nil {
-					t.Errorf("Expected file '%s' to exist.", path)
+		{
+			Name:    "Find by amp model",
+			Command: "find",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+				"amp=Metal Clean T",
+			},
+			Expected: filepath.Join(PresetsFolder, "Amps", "Amplitube", "Metal", "Metal Clean T"+PresetExtension),
+			CustomAssertion: func(workingDir string) error {
+				if strings.Contains(out.(*bytes.Buffer).String(), "Default"+PresetExtension) {
+					return errors.New("unexpected match " + out.(*bytes.Buffer).String())
 				}
-			}
-
-			for _, path := range tc.ExpectNotExist {
-				path = rehomePath(path, workingDirs)
-				if info, _ := os.Stat(path); info != nil {
-					t.Errorf("Expected file '%s' to not exists.", path)
+				return nil
+			},
+		},
+		{
+			Name:    "Find by fx in block",
+			Command: "find",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+				"StompB1.fx=b1333333-3333-3333-3333-333333333333",
+				"chain=Chain11",
+			},
+			Expected: filepath.Join(PresetsFolder, "Amps", "TestGearSparseSource"+PresetExtension),
+			CustomAssertion: func(workingDir string) error {
+				if strings.Contains(out.(*bytes.Buffer).String(), "TestGearEmpty"+PresetExtension) {
+					return errors.New("unexpected match " + out.(*bytes.Buffer).String())
 				}
-			}
-
-			database, err := sql.Open("sqlite3", strings.ReplaceAll(filepath.Join(workingDirs[0], "Presets.db"), "\\", "/"))
-
-			if err != nil {
-				log.Fatal("Failed validating result: ", err)
-			}
-
-			statement, err := database.Prepare("select count(id) from pXcPresets where OriginalFileName = ?")
-
-			if err != nil {
-				log.Fatal("Failed validating result: ", err)
-			}
-
-			for _, path := range tc.ExpectDBExists {
-				path = filepath.Join(filepath.Dir(workingDirs[0]), rehomePath(path, workingDirs))
-				rs, _ := statement.Query(path)
-				var count = -1
-				for rs.Next() {
-					rs.Scan(&count)
+				return nil
+			},
+		},
+		{
+			Name:    "Find by wildcard attribute comparison",
+			Command: "find",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+				"AmpA.Amp.Gain_*>5.2",
+			},
+			Expected: filepath.Join(PresetsFolder, "Amps", "Amplitube", "Metal", "Metal Clean T"+PresetExtension),
+			CustomAssertion: func(workingDir string) error {
+				if strings.Contains(out.(*bytes.Buffer).String(), "Default"+PresetExtension) {
+					return errors.New("unexpected match " + out.(*bytes.Buffer).String())
 				}
-				if count != 1 {
-					t.Errorf("Incorrect number of database record of %d for file '%s'; expected 1", count, path)
+				return nil
+			},
+		},
+		{
+			Name:    "Find by metadata as table",
+			Command: "find",
+			Args: []string{
+				"-t",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+				"MetaInfo.KeyWords~bivalve",
+				"db.Name=BiValve",
+			},
+			Expected: "BiValve  Chain11  Bi-Valve",
+		},
+		{
+			Name:    "Find by empty database column",
+			Command: "find",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+				"db.Band=",
+			},
+			CustomSetup: func(workingDirs []string) {
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDirs[0], "Presets.db"))
+				defer database.Close()
+				database.Exec("update pXcPresets set Band = NULL where OriginalFileName = ?", filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+			},
+			Expected: "BiValve.at5p",
+		},
+		{
+			Name:    "Find does not match NULL as text",
+			Command: "find",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+				"db.Band~nil",
+			},
+			CustomSetup: func(workingDirs []string) {
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDirs[0], "Presets.db"))
+				defer database.Close()
+				database.Exec("update pXcPresets set Band = NULL where OriginalFileName = ?", filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+			},
+			CustomAssertion: func(workingDir string) error {
+				if strings.Contains(out.(*bytes.Buffer).String(), "BiValve") {
+					return errors.New("NULL matched as text")
 				}
-			}
-
-			for _, path := range tc.ExpectDBNotExist {
-				path = filepath.Join(filepath.Dir(workingDirs[0]), rehomePath(path, workingDirs))
-				rs, _ := statement.Query(path)
-				var count = -1
-				for rs.Next() {
-					rs.Scan(&count)
+				return nil
+			},
+		},
+		{
+			Name:    "Find by unknown database column",
+			Command: "find",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+				"db.NoSuchColumn=1",
+			},
+			ExpectedError: "no such column: NoSuchColumn",
+		},
+		{
+			Name:    "Find invalid filter",
+			Command: "find",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder),
+				"AmpA",
+			},
+			ExpectedError: "invalid filter AmpA",
+		},
+		{
+			Name:    "Diff identical presets",
+			Command: "diff",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps2", "Amplitube", "American Tube Clean 1.at5p"),
+			},
+			Expected: "presets are identical",
+		},
+		{
+			Name:    "Diff amp and cab models",
+			Command: "diff",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Amplitube", "Metal", "Metal Clean T.at5p"),
+			},
+			Expected: `~ AmpA: American Tube Clean 1 -> Metal Clean T
+~ AmpB: Brit 8000 -> Metal Clean T`,
+		},
+		{
+			Name:    "Diff fx slots",
+			Command: "diff",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource2.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Amps", "TestGearSparseSource2.at5p")
+				preset, _ := readPresetFile(file)
+				stomp := preset.child("StompB1")
+				stomp.setAttr("Stomp0", EmptySlotGUID)
+				stomp.setAttr("Stomp5", "b1000000-0000-0000-0000-000000000000")
+				stomp.replaceChild("Slot5", &Element{XMLName: xml.Name{Local: "Slot5"}, Attrs: []xml.Attr{{Name: xml.Name{Local: "Name"}, Value: "Changed"}}})
+				writePresetFile(file, preset)
+			},
+			Expected: `> StompB1.Slot0: b1000000-0000-0000-0000-000000000000 -> Slot5
+~ StompB1.Slot5.Name: StompB1_Test0 -> Changed`,
+		},
+		{
+			Name:    "Diff as JSON",
+			Command: "diff",
+			Args: []string{
+				"-j",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSource.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+			},
+			Expected: `{
+        "path": "StompA1.Slot1",
+        "type": "removed",
+        "old": "a1111111-1111-1111-1111-111111111111"
+    }`,
+		},
+		{
+			Name:    "Diff requires two presets",
+			Command: "diff",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			ExpectedError: "diff requires two presets",
+		},
+		{
+			Name:    "Merge non-overlapping changes",
+			Command: "merge",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Ours.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Theirs.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				folder := filepath.Join(workingDirs[0], PresetsFolder, "Amps")
+				ours, _ := readPresetFile(filepath.Join(folder, "Default.at5p"))
+				ours.child("AmpA").child("Amp").setAttr("Gain_AmericanTubeClean", "6")
+				writePresetFile(filepath.Join(folder, "Ours.at5p"), ours)
+				theirs, _ := readPresetFile(filepath.Join(folder, "Default.at5p"))
+				theirs.child("StompA1").setAttr("Stomp0", "a1000000-0000-0000-0000-000000000000")
+				theirs.child("AmpA").child("Amp").setAttr("Bass_AmericanTubeClean", "7")
+				writePresetFile(filepath.Join(folder, "Theirs.at5p"), theirs)
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Ours.at5p"))
+				if err != nil {
+					return err
 				}
-				if count > 0 {
-					t.Errorf("Incorrect number of database record of %d for file '%s'; expected 0", count, path)
+				amp := preset.child("AmpA").child("Amp")
+				if amp.attr("Gain_AmericanTubeClean") != "6" || amp.attr("Bass_AmericanTubeClean") != "7" {
+					return errors.New("amp changes not merged")
 				}
-			}
-
-			if tc.CustomAssertion != nil {
-				if err = tc.CustomAssertion(workingDirs[0]); err != nil {
-					t.Error(err)
+				if preset.child("StompA1").attr("Stomp0") != "a1000000-0000-0000-0000-000000000000" {
+					return errors.New("fx change not merged")
 				}
-			}
-
-			statement.Close()
-			database.Close()
-
-		})
-	}
-
-}
-
-func setupData() string {
-
-	toCopy := map[string]string{}
-
-	randomUUID, _ := uuid.NewRandom()
-
-	pwd, _ := os.Getwd()
-
-	workingDir := filepath.Join(pwd, randomUUID.String())
-
-	filepath.Walk("testdata", func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			log.Fatal("Failed setting up test: ", err)
-		}
-		source := filepath.Join(pwd, path)
-		if !info.IsDir() {
-			toCopy[source] = strings.ReplaceAll(source, string(filepath.Separator)+"testdata"+string(filepath.Separator), string(filepath.Separator)+randomUUID.String()+string(filepath.Separator))
-		}
-		return nil
-	})
-
-	for source, target := range toCopy {
-		os.MkdirAll(filepath.Dir(target), 0775)
-		data, _ := ioutil.ReadFile(source)
-		ioutil.WriteFile(target, data, 0664)
-	}
-
-	filepath.Walk(workingDir, func(path string, info os.FileInfo, err error) error {
-		if filepath.Base(path) == EmptyPlaceholder {
-			os.Remove(path)
-		}
-		return nil
-	})
-
-	database, err := sql.Open("sqlite3", strings.ReplaceAll(filepath.Join(pwd, randomUUID.String(), "Presets.db"), "\\", "/"))
-
-	if err != nil {
-		log.Fatal("Failed setting up test: ", err)
-	}
-
-	tx, err := database.Begin()
-
-	if err != nil {
-		database.Close()
-		log.Fatal("Failed setting up test: ", err)
-	}
+				return nil
+			},
+		},
+		{
+			Name:    "Merge conflicting changes",
+			Command: "merge",
+			Args: []string{
+				"-o",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Merged.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Ours.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Theirs.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				folder := filepath.Join(workingDirs[0], PresetsFolder, "Amps")
+				ours, _ := readPresetFile(filepath.Join(folder, "Default.at5p"))
+				ours.child("AmpA").child("Amp").setAttr("Gain_AmericanTubeClean", "6")
+				ours.child("StompA1").setAttr("Stomp0", "a1000000-0000-0000-0000-000000000000")
+				writePresetFile(filepath.Join(folder, "Ours.at5p"), ours)
+				theirs, _ := readPresetFile(filepath.Join(folder, "Default.at5p"))
+				theirs.child("AmpA").child("Amp").setAttr("Gain_AmericanTubeClean", "7")
+				theirs.child("StompA1").setAttr("Stomp0", "a1111111-1111-1111-1111-111111111111")
+				writePresetFile(filepath.Join(folder, "Theirs.at5p"), theirs)
+			},
+			ExpectedError: "2 merge conflict(s)",
+			Expected: `CONFLICT StompA1.Slot0: base (empty), ours a1000000-0000-0000-0000-000000000000, theirs a1111111-1111-1111-1111-111111111111
+CONFLICT AmpA.Amp.Gain_AmericanTubeClean: base 5, ours 6, theirs 7`,
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Merged.at5p"),
+			},
+		},
+		{
+			Name:    "Merge requires three presets",
+			Command: "merge",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			ExpectedError: "merge requires a base, ours and theirs preset",
+		},
+		{
+			Name:    "Export preset as JSON",
+			Command: "export",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			Expected: `{
+                "name": "AmpA",
+                "model": "American Tube Clean 1",
+                "attributes": {
+                    "Bypass": "0",`,
+		},
+		{
+			Name:    "Export preset as YAML",
+			Command: "export",
+			Args: []string{
+				"-format",
+				"yaml",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+			},
+			Expected: `      slots:
+        - slot: 0
+          guid: "b1000000-0000-0000-0000-000000000000"
+          attributes:
+            Name: "StompB1_Test0"`,
+		},
+		{
+			Name:    "Export unsupported format",
+			Command: "export",
+			Args: []string{
+				"-format",
+				"toml",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			ExpectedError: "unsupported export format toml",
+		},
+		{
+			Name:    "Import exported document",
+			Command: "import-doc",
+			Args: []string{
+				filepath.Join(TestDataRoot, "TestGearSource.yaml"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Empty"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("export", []string{"-format", "yaml", "-o", filepath.Join(workingDirs[0], "TestGearSource.yaml"), filepath.Join(workingDirs[0], PresetsFolder, "Amps", "TestGearSource.at5p")})
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Empty", "TestGearSource.at5p"),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Empty", "TestGearSource.at5p"),
+			},
+			CustomAssertion: func(workingDir string) error {
+				original, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSource.at5p"))
+				imported, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Empty", "TestGearSource.at5p"))
+				if err != nil {
+					return err
+				}
+				if changes := diffPresets(original, imported); len(changes) > 0 {
+					return errors.New(fmt.Sprint("imported preset differs: ", changes))
+				}
+				if original.attr("GUID") == imported.attr("GUID") {
+					return errors.New("imported preset GUID not regenerated")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Import document with gear names",
+			Command: "import-doc",
+			Args: []string{
+				filepath.Join(TestDataRoot, "Generated.yaml"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Generated.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ioutil.WriteFile(filepath.Join(workingDirs[0], "Generated.yaml"), []byte(`# generated
+name: Generated
+preset:
+  name: Preset
+  attributes:
+    Version: 1
+    Format: at5p
+  children:
+  - name: Chain
+    attributes:
+      Preset: Chain11
+  - name: AmpA
+    model: Tiny Terror
+    attributes:
+      Bypass: 0
+  - name: StompA1
+    attributes:
+      Bypass: 0
+    slots:
+    - slot: 1
+      guid: 'a1111111-1111-1111-1111-111111111111'
+`), 0664)
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Generated.at5p"),
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Generated.at5p"))
+				if err != nil {
+					return err
+				}
+				if preset.child("AmpA").attr("Model") != "99e446c7-49df-45b1-bff9-26d95e10c763" {
+					return errors.New("amp model not resolved; was " + preset.child("AmpA").attr("Model"))
+				}
+				stomp := preset.child("StompA1")
+				if stomp.attr("Stomp0") != EmptySlotGUID || stomp.attr("Stomp1") != "a1111111-1111-1111-1111-111111111111" || stomp.attr("Stomp5") != EmptySlotGUID {
+					return errors.New("fx slots not rebuilt")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Import document with flow mappings",
+			Command: "import-doc",
+			Args: []string{
+				filepath.Join(TestDataRoot, "Flow.yaml"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Flow.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ioutil.WriteFile(filepath.Join(workingDirs[0], "Flow.yaml"), []byte(`name: Flow
+preset:
+  name: Preset
+  attributes: {Version: 1, Format: at5p}
+  children:
+  - {name: Chain, attributes: {Preset: Chain11}}
+  - name: Input
+    attributes: {Input: "2"}
+`), 0664)
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Flow.at5p"),
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Flow.at5p"))
+				if err != nil {
+					return err
+				}
+				if preset.child("Chain").attr("Preset") != "Chain11" || preset.child("Input").attr("Input") != "2" {
+					return errors.New("flow mapping attributes not imported")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Get metadata",
+			Command: "meta",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+				"KeyWords",
+				"Rating",
+			},
+			Expected: `BiValve.at5p
+    KeyWords: BiValve Head
+    Rating: `,
+		},
+		{
+			Name:    "Set metadata",
+			Command: "meta",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+				"Band=The Test Band",
+				"Style=Metal",
+				"Rating=4",
+				"KeyWords=Bi Valve",
+			},
+			CustomAssertion: func(workingDir string) error {
+				file := filepath.Join(workingDir, PresetsFolder, "Amps", "THD", "BiValve.at5p")
+				preset, _ := readPresetFile(file)
+				metaInfo := preset.child("MetaInfo")
+				if metaInfo.attr("Band") != "The Test Band" || metaInfo.attr("Style") != "Metal" || metaInfo.attr("KeyWords") != "Bi Valve" {
+					return errors.New("MetaInfo not updated")
+				}
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDir, "Presets.db"))
+				defer database.Close()
+				var band, genre, keywords string
+				var rating float64
+				err := database.QueryRow("select b.Description, g.Description, p.Keywords, p.Rating from pXcPresets p join pXcBands b on b.Id = p.Band join pXcGenre g on g.Id = p.ATGenre where p.OriginalFileName = ?", file).Scan(&band, &genre, &keywords, &rating)
+				if err != nil {
+					return err
+				}
+				if band != "The Test Band" || genre != "Metal" || keywords != "Bi Valve" || rating != 4 {
+					return errors.New(fmt.Sprint("database not updated: ", band, genre, keywords, rating))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set metadata recursively",
+			Command: "meta",
+			Args: []string{
+				"-r",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps2"),
+				"Favorite=1",
+				"Song=Test Song",
+			},
+			CustomAssertion: func(workingDir string) error {
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDir, "Presets.db"))
+				defer database.Close()
+				var count int
+				database.QueryRow("select count(*) from pXcPresets where Favorite = 1 and Song = 'Test Song' and OriginalFileName like ?", filepath.Join(workingDir, PresetsFolder, "Amps2")+"%").Scan(&count)
+				if count != 3 {
+					return errors.New(fmt.Sprint("expected 3 updated records; was ", count))
+				}
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps2", "Amplitube", "SVX", "SVX-4B.at5p"))
+				if preset.child("MetaInfo").attr("Song") != "Test Song" {
+					return errors.New("MetaInfo not updated")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Meta unknown field",
+			Command: "meta",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Colour=Red",
+			},
+			ExpectedError: "unknown metadata field Colour",
+		},
+		{
+			Name:    "Doctor reports inconsistencies",
+			Command: "doctor",
+			Args: []string{
+				TestDataRoot,
+			},
+			CustomSetup: func(workingDirs []string) {
+				os.Remove(filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDirs[0], "Presets.db"))
+				database.Exec("update pXcPresets set Name = 'Wrong' where OriginalFileName = ?", filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p"))
+				database.Close()
+			},
+			CustomAssertion: func(workingDir string) error {
+				output := out.(*bytes.Buffer).String()
+				for _, expected := range []string{
+					"invalid Name: " + filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p") + " (Wrong)",
+					"orphaned record: " + filepath.Join(workingDir, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+					"missing record: " + filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSource.at5p"),
+					"duplicate GUID: " + filepath.Join(workingDir, PresetsFolder, "Amps2", "Amplitube", "SVX", "SVX-4B.at5p"),
+					"issue(s) found; run with -fix to repair",
+				} {
+					if !strings.Contains(output, expected) {
+						return errors.New("expected '" + expected + "' in " + output)
+					}
+				}
+				return nil
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension),
+			},
+		},
+		{
+			Name:    "Doctor fixes inconsistencies",
+			Command: "doctor",
+			Args: []string{
+				"-fix",
+				TestDataRoot,
+			},
+			CustomSetup: func(workingDirs []string) {
+				os.Remove(filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSource"+PresetExtension),
+			},
+			ExpectDBNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension),
+			},
+			CustomAssertion: func(workingDir string) error {
+				out = bytes.NewBuffer(nil)
+				if err := ExecuteCommand("doctor", []string{workingDir}); err != nil {
+					return err
+				}
+				if !strings.Contains(out.(*bytes.Buffer).String(), "no issues found") {
+					return errors.New("issues remain after fix: " + out.(*bytes.Buffer).String())
+				}
+				return nil
+			},
+		},
+		{
+			Name:          "Doctor requires profile",
+			Command:       "doctor",
+			Args:          []string{filepath.Join(TestDataRoot, PresetsFolder)},
+			ExpectedError: "arg must be the root of an Amplitube profile",
+		},
+		{
+			Name:    "Dry run copy",
+			Command: "cp",
+			Args: []string{
+				"-n",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Copy.at5p"),
+			},
+			Expected: "would write ",
+			CustomAssertion: func(workingDir string) error {
+				if !strings.Contains(out.(*bytes.Buffer).String(), "would execute insert into pXcPresets") {
+					return errors.New("expected SQL to be reported: " + out.(*bytes.Buffer).String())
+				}
+				return nil
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Copy.at5p"),
+			},
+			ExpectDBNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Copy.at5p"),
+			},
+		},
+		{
+			Name:    "Dry run global flag remove",
+			Command: "-n",
+			Args: []string{
+				"rm",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			Expected: "would remove ",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+		},
+		{
+			Name:    "Dry run move",
+			Command: "mv",
+			Args: []string{
+				"--dry-run",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps2"),
+			},
+			Expected: "would rename ",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps2", "THD", "BiValve.at5p"),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+			},
+		},
+		{
+			Name:    "Dry run set gear",
+			Command: "sg",
+			Args: []string{
+				"-n",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Preset.AmpA.Amp.Gain_AmericanTubeClean=9",
+			},
+			Expected: "would write ",
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if preset.child("AmpA").child("Amp").attr("Gain_AmericanTubeClean") != "5" {
+					return errors.New("preset changed during dry run")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Undo copy",
+			Command: "undo",
+			Args: []string{
+				filepath.Join(TestDataRoot),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("cp", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p"),
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "New", "Copy.at5p"),
+				})
+			},
+			Expected: "cp ",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "New"),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			ExpectDBNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "New", "Copy.at5p"),
+			},
+		},
+		{
+			Name:    "Undo recursive remove",
+			Command: "undo",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("rm", []string{
+					"-r",
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD"),
+				})
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+			},
+		},
+		{
+			Name:    "Undo move",
+			Command: "undo",
+			Args: []string{
+				filepath.Join(TestDataRoot),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("mv", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD"),
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps2"),
+				})
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps2", "THD", "BiValve.at5p"),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+			},
+			ExpectDBNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps2", "THD", "BiValve.at5p"),
+			},
+		},
+		{
+			Name:    "Undo metadata removes added lookup values",
+			Command: "undo",
+			Args: []string{
+				filepath.Join(TestDataRoot),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("meta", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+					"Band=The Test Band",
+				})
+			},
+			CustomAssertion: func(workingDir string) error {
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDir, "Presets.db"))
+				defer database.Close()
+				var count int
+				database.QueryRow("select count(*) from pXcBands where Description = 'The Test Band'").Scan(&count)
+				if count != 0 {
+					return errors.New("band added by meta not removed")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Undo last two operations",
+			Command: "undo",
+			Args: []string{
+				filepath.Join(TestDataRoot),
+				"2",
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("sg", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p"),
+					"Preset.AmpA.Amp.Gain_AmericanTubeClean=9",
+				})
+				ExecuteCommand("meta", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p"),
+					"Rating=4",
+				})
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if preset.child("AmpA").child("Amp").attr("Gain_AmericanTubeClean") != "5" {
+					return errors.New("gear setting not restored")
+				}
+				entries, _ := readJournal(workingDir)
+				if len(entries) != 0 {
+					return errors.New("journal entries not removed")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Undo with empty journal",
+			Command: "undo",
+			Args: []string{
+				filepath.Join(TestDataRoot),
+			},
+			ExpectedError: "nothing to undo",
+		},
+		{
+			Name:    "Undo more operations than recorded",
+			Command: "undo",
+			Args: []string{
+				filepath.Join(TestDataRoot),
+				"2",
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("mkdir", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "New"),
+				})
+			},
+			ExpectedError: "only 1 operation(s) to undo",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "New"),
+			},
+		},
+		{
+			Name:    "History",
+			Command: "history",
+			Args: []string{
+				filepath.Join(TestDataRoot),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("mkdir", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "New"),
+				})
+				ExecuteCommand("ls", []string{
+					filepath.Join(workingDirs[0], PresetsFolder),
+				})
+				ExecuteCommand("rm", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "New"),
+				})
+			},
+			CustomAssertion: func(workingDir string) error {
+				lines := strings.Split(strings.TrimSpace(out.(*bytes.Buffer).String()), "\n")
+				if len(lines) != 2 || !strings.HasPrefix(lines[0], "2  ") || !strings.Contains(lines[0], "  rm ") || !strings.HasPrefix(lines[1], "1  ") || !strings.Contains(lines[1], "  mkdir ") {
+					return errors.New("unexpected history: " + out.(*bytes.Buffer).String())
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "History with empty journal",
+			Command: "history",
+			Args: []string{
+				filepath.Join(TestDataRoot),
+			},
+			Expected: "no operations recorded",
+		},
+		{
+			Name:    "List gear Amplitube 4 preset",
+			Command: "lsg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+			},
+			CustomSetup: setupV4Preset,
+			Expected: `Default.at4p
+    AmpA: American Tube Clean 1
+    CabA: 4x10 Open Vintage
+         SpeakerModel0: a3cc18b8-e9b4-49e3-b1ce-34c69b310b83
+         SpeakerModel1: a3cc18b8-e9b4-49e3-b1ce-34c69b310b83
+         SpeakerModel2: a3cc18b8-e9b4-49e3-b1ce-34c69b310b83
+         SpeakerModel3: a3cc18b8-e9b4-49e3-b1ce-34c69b310b83
+         RoomType: Mid Studio
+         RoomMicType: Condenser 87
+         Mic0Model: Dynamic 57
+         Mic1Model: Condenser 87
+    StompA
+        Slot0: Delay
+    LoopFxA
+        Slot1: Analog Delay`,
+		},
+		{
+			Name:    "List gear Amplitube 4 dual amp chain",
+			Command: "lsg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				setupV4Preset(workingDirs)
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Default.at4p")
+				data, _ := ioutil.ReadFile(file)
+				ioutil.WriteFile(file, []byte(strings.Replace(string(data), `<Chain Preset="0"`, `<Chain Preset="2"`, 1)), 0664)
+			},
+			Expected: `    AmpB: American Tube Clean 1`,
+		},
+		{
+			Name:    "List gear details Amplitube 4 preset",
+			Command: "lsg",
+			Args: []string{
+				"-d",
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+			},
+			CustomSetup: setupV4Preset,
+			Expected:    "AmpFlexiGain: 5.000000",
+		},
+		{
+			Name:    "Copy gear Amplitube 4 stomps",
+			Command: "cpg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				"StompA",
+				"StompB",
+			},
+			CustomSetup: setupV4Preset,
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Default.at4p"))
+				stomp := preset.child("StompB")
+				if stomp.attr("Stomp0") != "e11b1dc5-1f7d-42ad-af30-0539b3646b3c" || stomp.attr("Stomp1") != EmptySlotGUID {
+					return errors.New("StompB not copied: " + stomp.attr("Stomp0"))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Copy gear Amplitube 4 loop effects",
+			Command: "cpg",
+			Args: []string{
+				"-o",
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				"LoopFxA",
+				"RackB",
+			},
+			CustomSetup: setupV4Preset,
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Default.at4p"))
+				rack := preset.child("RackB")
+				if rack.attr("Rack1") != "b756e0c1-7685-4b38-bccc-b74c7febd868" || rack.attr("Rack0") != EmptyRackGUID || rack.hasAttr("Stomp0") {
+					return errors.New("RackB not copied")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Copy gear between Amplitube 4 and 5 presets",
+			Command: "cpg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				"AmpA",
+			},
+			CustomSetup: setupV4Preset,
+			Expected:    "skipped; gear cannot be copied between at5p and at4p presets",
+		},
+		{
+			Name:    "Remove gear Amplitube 4 loop effects",
+			Command: "rmg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				"LoopFxA",
+			},
+			CustomSetup: setupV4Preset,
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Default.at4p"))
+				loop := preset.child("LoopFxA")
+				for i := 0; i < 4; i++ {
+					if loop.attr("Rack"+strconv.Itoa(i)) != EmptyRackGUID {
+						return errors.New("LoopFxA not cleared")
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set gear Amplitube 4 amp",
+			Command: "sg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				"Preset.AmpA.Amp.AmpFlexiGain=7.500000",
+			},
+			CustomSetup: setupV4Preset,
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Default.at4p"))
+				if preset.child("AmpA").child("Amp").attr("AmpFlexiGain") != "7.500000" {
+					return errors.New("AmpFlexiGain not set")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Convert Amplitube 4 preset",
+			Command: "convert",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+			},
+			CustomSetup: setupV4Preset,
+			Expected:    "converted ",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at5p"),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at5p"),
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				switch {
+				case preset.attr("Format") != "at5p" || preset.attr("PresetBPM") != "120":
+					return errors.New("preset root not converted")
+				case preset.child("Chain").attr("Preset") != "Chain11":
+					return errors.New("chain not converted")
+				case preset.child("AmpA").child("Amp").attr("Reverb_AmericanTubeClean") != "1.25":
+					return errors.New("amp parameters not converted")
+				case preset.child("StompA1").attr("Stomp0") != "e11b1dc5-1f7d-42ad-af30-0539b3646b3c":
+					return errors.New("stomps not converted")
+				case preset.child("LoopFxA").attr("Stomp1") != "b756e0c1-7685-4b38-bccc-b74c7febd868" || preset.child("LoopFxA").hasAttr("Rack1"):
+					return errors.New("loop effects not converted")
+				case preset.child("CabA").attr("SpeakerModel0") != "a3cc18b8e9b449e3b1ce34c69b310b83":
+					return errors.New("speakers not converted")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Convert Amplitube 4 preset with unmapped gear",
+			Command: "convert",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Converted.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				setupV4Preset(workingDirs)
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Default.at4p")
+				data, _ := ioutil.ReadFile(file)
+				preset := strings.Replace(string(data), `<Chain Preset="0"`, `<Chain Preset="7"`, 1)
+				preset = strings.Replace(preset, `Model="71a76a9f-cf70-4f59-971f-9864a055523c"`, `Model="99e446c7-49df-45b1-bff9-26d95e10c763"`, 1)
+				preset = strings.Replace(preset, `OutputPan="0.500000" Rack0="d3b93507-420b-48f6-ac82-6f6f2892726c" Rack1="d3b93507-420b-48f6-ac82-6f6f2892726c" Rack2="d3b93507-420b-48f6-ac82-6f6f2892726c"`, `OutputPan="0.500000" Rack0="d3b93507-420b-48f6-ac82-6f6f2892726c" Rack1="d3b93507-420b-48f6-ac82-6f6f2892726c" Rack2="e11b1dc5-1f7d-42ad-af30-0539b3646b3c"`, 2)
+				ioutil.WriteFile(file, []byte(preset), 0664)
+			},
+			Expected: `Default.at4p: chain preset 7 not mapped; using Chain12
+Default.at4p: AmpA: parameters of Tiny Terror not converted
+Default.at4p: RackA.Slot2 Delay dropped; RackA has 2 slots`,
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Converted.at5p"),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Converted.at5p"),
+			},
+		},
+		{
+			Name:    "Convert Amplitube 4 preset with catalog amp parameters",
+			Command: "convert",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Converted.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				setupV4Preset(workingDirs)
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Default.at4p")
+				data, _ := ioutil.ReadFile(file)
+				preset := strings.Replace(string(data), `Model="71a76a9f-cf70-4f59-971f-9864a055523c"`, `Model="8fe96936-5178-4950-9b80-d89c32534bad"`, 1)
+				preset = strings.Replace(preset, `AmpFlexiBass="5.000000"`, `AmpFlexiBass="0.000010"`, 1)
+				ioutil.WriteFile(file, []byte(preset), 0664)
+			},
+			Expected: `Default.at4p: AmpA: parameters of Brit 8000 not converted`,
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Converted.at5p"))
+				if err != nil {
+					return err
+				}
+				amp := preset.child("AmpA").child("Amp")
+				if amp.attr("Bass_JCM800AT4") != "0.00001" || amp.attr("Treble_JCM800AT4") != "5" {
+					return errors.New("amp parameters not converted; bass was " + amp.attr("Bass_JCM800AT4"))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Convert Amplitube 5 preset",
+			Command: "convert",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			ExpectedError: "not an Amplitube 4 preset",
+		},
+		{
+			Name:    "Convert existing target",
+			Command: "convert",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+			},
+			CustomSetup: setupV4Preset,
+			Expected:    "Default.at5p already exists; skipped",
+		},
+		{
+			Name:    "Chain single to dual initialises new path",
+			Command: "chain",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Chain12",
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("sg", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p"),
+					"Preset.StompB2.Stomp0=e11b1dc5-1f7d-42ad-af30-0539b3646b3c",
+				})
+			},
+			Expected: "Default.at5p: Chain11 -> Chain12",
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if preset.child("Chain").attr("Preset") != "Chain12" {
+					return errors.New("chain not changed")
+				}
+				if preset.child("StompB2").attr("Stomp0") != EmptySlotGUID {
+					return errors.New("StompB2 not initialised")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Chain single to dual mirrors path",
+			Command: "chain",
+			Args: []string{
+				"-m",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Chain12",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if preset.child("AmpB").attr("Model") != preset.child("AmpA").attr("Model") || preset.child("CabB").attr("CabModel") != preset.child("CabA").attr("CabModel") {
+					return errors.New("first path not mirrored")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Chain dual to single warns of lost gear",
+			Command: "chain",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Chain11",
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("sg", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p"),
+					"Preset.Chain.Preset=Chain12",
+				})
+			},
+			Expected:      "Default.at5p: AmpB: Brit 8000 would be lost",
+			ExpectedError: "active gear would be lost",
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if preset.child("Chain").attr("Preset") != "Chain12" {
+					return errors.New("chain changed")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Chain dual to single folds effects",
+			Command: "chain",
+			Args: []string{
+				"-fold",
+				"-f",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Chain11",
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("chain", []string{
+					"-m",
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p"),
+					"Chain12",
+				})
+				ExecuteCommand("sg", []string{
+					filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p"),
+					"Preset.StompB2.Stomp0=e11b1dc5-1f7d-42ad-af30-0539b3646b3c",
+				})
+			},
+			Expected: "Default.at5p: Chain12 -> Chain11",
+			CustomAssertion: func(workingDir string) error {
+				if strings.Contains(out.(*bytes.Buffer).String(), "would be lost") {
+					return errors.New("unexpected warning: " + out.(*bytes.Buffer).String())
+				}
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if preset.child("StompB1").attr("Stomp0") != "e11b1dc5-1f7d-42ad-af30-0539b3646b3c" {
+					return errors.New("StompB2 not folded into StompB1")
+				}
+				if preset.child("StompB2").attr("Stomp0") != EmptySlotGUID {
+					return errors.New("StompB2 not reset")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Chain unknown",
+			Command: "chain",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Chain33",
+			},
+			ExpectedError: "unknown chain Chain33",
+		},
+		{
+			Name:    "Chain Amplitube 4 preset",
+			Command: "chain",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+				"Chain12",
+			},
+			CustomSetup:   setupV4Preset,
+			ExpectedError: "chain not supported for at4p presets",
+		},
+		{
+			Name:    "Move gear within block",
+			Command: "mvg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompB1.Slot3",
+				"StompB1.Slot0",
+			},
+			CustomAssertion: func(workingDir string) error {
+				file := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource.at5p")
+				if gear := stompGUIDs(file, "StompB1"); gear != "b1333333,b1000000,b1111111,b1222222,-,-" {
+					return errors.New("unexpected StompB1 " + gear)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Move gear between blocks",
+			Command: "mvg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompA1.Slot0",
+				"LoopFxA.Slot0",
+			},
+			CustomAssertion: func(workingDir string) error {
+				file := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource.at5p")
+				if gear := stompGUIDs(file, "StompA1"); gear != "-,-,-,-,-,-" {
+					return errors.New("unexpected StompA1 " + gear)
+				}
+				if gear := stompGUIDs(file, "LoopFxA"); gear != "a1000000,la000000,la111111,-" {
+					return errors.New("unexpected LoopFxA " + gear)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Move gear to end of block",
+			Command: "mvg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompB1.Slot0",
+				"RackA",
+			},
+			CustomAssertion: func(workingDir string) error {
+				file := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource.at5p")
+				if gear := stompGUIDs(file, "StompB1"); gear != "-,b1111111,b1222222,b1333333,-,-" {
+					return errors.New("unexpected StompB1 " + gear)
+				}
+				if gear := stompGUIDs(file, "RackA"); gear != "ra000000,b1000000" {
+					return errors.New("unexpected RackA " + gear)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Move gear block exceeds slots",
+			Command: "mvg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompB1",
+				"LoopFxA",
+			},
+			ExpectedError: "LoopFxA has only 4 slots",
+		},
+		{
+			Name:    "Move gear amp",
+			Command: "mvg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"AmpA",
+				"AmpB",
+			},
+			ExpectedError: "move gear only supported for effects",
+		},
+		{
+			Name:    "Swap slots",
+			Command: "swap",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompB1.Slot0",
+				"StompB1.Slot3",
+			},
+			CustomAssertion: func(workingDir string) error {
+				file := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource.at5p")
+				if gear := stompGUIDs(file, "StompB1"); gear != "b1333333,b1111111,b1222222,b1000000,-,-" {
+					return errors.New("unexpected StompB1 " + gear)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Swap fx blocks",
+			Command: "swap",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompA1",
+				"RackA",
+			},
+			CustomAssertion: func(workingDir string) error {
+				file := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource.at5p")
+				if gear := stompGUIDs(file, "StompA1"); gear != "ra000000,-,-,-,-,-" {
+					return errors.New("unexpected StompA1 " + gear)
+				}
+				if gear := stompGUIDs(file, "RackA"); gear != "a1000000,-" {
+					return errors.New("unexpected RackA " + gear)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Swap fx blocks exceeds slots",
+			Command: "swap",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompB1",
+				"RackA",
+			},
+			ExpectedError: "RackA has only 2 slots",
+		},
+		{
+			Name:    "Swap block with slot",
+			Command: "swap",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompB1",
+				"RackA.Slot0",
+			},
+			ExpectedError: "cannot swap a block with a slot",
+		},
+		{
+			Name:    "Swap incompatible blocks",
+			Command: "swap",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"AmpA",
+				"StompB1",
+			},
+			ExpectedError: "incompatible slots",
+		},
+		{
+			Name:    "Swap invalid slot",
+			Command: "swap",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"RackA.Slot2",
+				"RackA.Slot0",
+			},
+			ExpectedError: "invalid slot RackA.Slot2",
+		},
+		{
+			Name:    "Swap pedal into rack slot",
+			Command: "swap",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompA1.Slot0",
+				"RackA.Slot0",
+			},
+			CustomSetup: func(workingDirs []string) {
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Amps", "TestGearSparseSource.at5p")
+				preset, _ := readPresetFile(file)
+				preset.child("StompA1").setAttr("Stomp0", "e11b1dc5-1f7d-42ad-af30-0539b3646b3c")
+				writePresetFile(file, preset)
+			},
+			ExpectedError: "Delay is a pedal and cannot go in RackA",
+		},
+		{
+			Name:    "Move rack effect into stomp block",
+			Command: "mvg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"RackA.Slot0",
+				"StompA1",
+			},
+			CustomSetup: func(workingDirs []string) {
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Amps", "TestGearSparseSource.at5p")
+				preset, _ := readPresetFile(file)
+				preset.child("RackA").setAttr("Stomp0", "59ab0817-b168-4bdc-b837-e3cba1efb2dd")
+				writePresetFile(file, preset)
+			},
+			ExpectedError: "Digital Reverb is a rack effect and cannot go in StompA1",
+		},
+		{
+			Name:    "Swap checks every preset before writing",
+			Command: "swap",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource*.at5p"),
+				"StompA1.Slot0",
+				"RackA.Slot0",
+			},
+			CustomSetup: func(workingDirs []string) {
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Amps", "TestGearSparseSource2.at5p")
+				preset, _ := readPresetFile(file)
+				preset.child("StompA1").setAttr("Stomp0", "e11b1dc5-1f7d-42ad-af30-0539b3646b3c")
+				writePresetFile(file, preset)
+			},
+			ExpectedError: "Delay is a pedal and cannot go in RackA",
+			CustomAssertion: func(workingDir string) error {
+				file := filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource.at5p")
+				if gear := stompGUIDs(file, "RackA"); gear != "ra000000,-" {
+					return errors.New("preset written before error: RackA " + gear)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Swap amps with cabs",
+			Command: "swap",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"AmpA",
+				"AmpB",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource.at5p"))
+				if preset.child("AmpA").attr("Model") != "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb" || preset.child("AmpB").attr("Model") != "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa" {
+					return errors.New("amps not swapped")
+				}
+				if preset.child("CabA").attr("CabModel") != "cb000000-0000-0000-0000-000000000000" || preset.child("CabB").attr("CabModel") != "ca000000-0000-0000-0000-000000000000" {
+					return errors.New("cabs not swapped")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Swap amps without cabs",
+			Command: "swap",
+			Args: []string{
+				"-c",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"AmpA",
+				"AmpB",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSparseSource.at5p"))
+				if preset.child("AmpA").attr("Model") != "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb" {
+					return errors.New("amps not swapped")
+				}
+				if preset.child("CabA").attr("CabModel") != "ca000000-0000-0000-0000-000000000000" {
+					return errors.New("cabs swapped")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set amp and cab parameters",
+			Command: "sg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Preset.CabA.Cab.RoomType=Small Studio",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				if value := preset.child("CabA").child("Cab").attr("RoomType"); value != "Small Studio" {
+					return errors.New("RoomType not set: " + value)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set studio parameter",
+			Command: "sg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Studio.DI_Level=-6",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				if value := preset.child("Studio").attr("DI_Level"); value != "-6" {
+					return errors.New("DI_Level not set: " + value)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set meta info keywords",
+			Command: "sg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+				"Preset.MetaInfo.KeyWords=Two Valves",
+			},
+			CustomAssertion: func(workingDir string) error {
+				file := filepath.Join(workingDir, PresetsFolder, "Amps", "THD", "BiValve.at5p")
+				preset, _ := readPresetFile(file)
+				if value := preset.child("MetaInfo").attr("KeyWords"); value != "Two Valves" {
+					return errors.New("KeyWords not set: " + value)
+				}
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDir, "Presets.db"))
+				defer database.Close()
+				var keywords string
+				if err := database.QueryRow("select Keywords from pXcPresets where OriginalFileName = ?", file).Scan(&keywords); err != nil {
+					return err
+				}
+				if keywords != "Two Valves" {
+					return errors.New("database not updated: " + keywords)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set unknown element",
+			Command: "sg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"AmpA.Amplifier.Gain=5",
+			},
+			ExpectedError: "invalid or unsupported path Preset.AmpA.Amplifier.Gain",
+		},
+		{
+			Name:    "Set unknown attribute",
+			Command: "sg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Preset.CabA.Cab.Unknown=5",
+			},
+			ExpectedError: "attribute Unknown not found in Preset.CabA.Cab",
+		},
+		{
+			Name:    "Set amp parameter recursively with unsupported path",
+			Command: "sg",
+			Args: []string{
+				"-r",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Amplitube"),
+				"AmpA.Amp.Gain_AmericanTubeClean=9",
+			},
+			ExpectedError: "attribute Gain_AmericanTubeClean not found in Preset.AmpA.Amp",
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Amplitube", "American Tube Clean 1.at5p"))
+				if err != nil {
+					return err
+				}
+				if value := preset.child("AmpA").child("Amp").attr("Gain_AmericanTubeClean"); value != "5" {
+					return errors.New("preset changed before error: " + value)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set effect by model name",
+			Command: "setg",
+			Args: []string{
+				"-force",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Preset.StompA1.Slot2",
+				"overdrive",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				block := preset.child("StompA1")
+				if block.attr("Stomp2") != "fd627f5e-ba11-4082-b546-a4f0b05985ff" || block.child("Slot2") == nil {
+					return errors.New("Slot2 not set: " + block.attr("Stomp2"))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set effect by partial model name",
+			Command: "setg",
+			Args: []string{
+				"-force",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"StompB1.Slot0",
+				"diode over",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				if guid := preset.child("StompB1").attr("Stomp0"); guid != "5e65abef-82eb-4995-b911-d5eca4f8291e" {
+					return errors.New("Slot0 not set: " + guid)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set effect by unknown model name",
+			Command: "setg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"StompA1.Slot2",
+				"Overdrive X",
+			},
+			ExpectedError: "unknown fx model Overdrive X; did you mean Overdrive",
+		},
+		{
+			Name:    "Set effect by ambiguous model name",
+			Command: "setg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"StompA1.Slot2",
+				"Overdr",
+			},
+			ExpectedError: "ambiguous fx model Overdr; did you mean Overdrive, Diode Overdrive",
+		},
+		{
+			Name:    "Set amp by model name",
+			Command: "setg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"AmpA.Model",
+				"bivalve",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				amp := preset.child("AmpA")
+				if amp.attr("Model") != "f058124b-498f-4899-8b29-35453d6aecff" || amp.child("Amp").attr("Gain_THDBiValve") != "5" {
+					return errors.New("amp not set: " + amp.attr("Model"))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set cab by model name",
+			Command: "setg",
+			Args: []string{
+				"-force",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"CabA.CabModel",
+				"Champion 600",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				if guid := preset.child("CabA").attr("CabModel"); guid != "4b4c561b-68d7-4311-ae31-2432817850bd" {
+					return errors.New("cab not set: " + guid)
+				}
+				if preset.child("CabA").hasAttr("SpeakerModel1") {
+					return errors.New("speakers of the previous cab kept")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set effect with parameters of a preset using it",
+			Command: "setg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"StompA1.Slot1",
+				"Overscream",
+			},
+			CustomSetup: func(workingDirs []string) {
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Amps", "TestGearSource.at5p")
+				preset, _ := readPresetFile(file)
+				block, _ := SchemaV5.block("StompB1")
+				slots := fxSlots(preset.child("StompB1"), block)
+				slot := &Element{XMLName: xml.Name{Local: "Slot1"}}
+				slot.putAttr("Drive", "7")
+				slots[1] = FxSlot{GUID: "fa1de2e2-102b-4edf-b3b5-23ceaeddedf0", Slot: slot}
+				setFxSlots(preset.child("StompB1"), block, slots)
+				writePresetFile(file, preset)
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				block := preset.child("StompA1")
+				if block.attr("Stomp1") != "fa1de2e2-102b-4edf-b3b5-23ceaeddedf0" || block.child("Slot1") == nil || block.child("Slot1").attr("Drive") != "7" {
+					return errors.New("Slot1 not set from the source preset: " + block.attr("Stomp1"))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set cab with speakers of a preset using it",
+			Command: "setg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"CabA.CabModel",
+				"Champion 600",
+			},
+			CustomSetup: func(workingDirs []string) {
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Amplitube", "American Tube Clean 1.at5p")
+				preset, _ := readPresetFile(file)
+				cab := preset.child("CabA")
+				cab.putAttr("CabModel", "4b4c561b-68d7-4311-ae31-2432817850bd")
+				fitCabSpeakers(cab, 1)
+				cab.putAttr("SpeakerModel0", "942153d281fb4b089fc20e07a34e9ca7")
+				writePresetFile(file, preset)
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				cab := preset.child("CabA")
+				if cab.attr("CabModel") != "4b4c561b-68d7-4311-ae31-2432817850bd" || cab.attr("SpeakerModel0") != "942153d281fb4b089fc20e07a34e9ca7" {
+					return errors.New("cab not set from the source preset: " + cab.attr("SpeakerModel0"))
+				}
+				if cab.hasAttr("SpeakerModel1") {
+					return errors.New("speakers of the previous cab kept")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set effect without parameters",
+			Command: "setg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"StompA1.Slot1",
+				"Overscream",
+			},
+			ExpectedError: "no parameters for fx model Overscream in the gear catalog or the presets of the profile; use -force",
+		},
+		{
+			Name:    "Set cab without speakers",
+			Command: "setg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"CabA.CabModel",
+				"Champion 600",
+			},
+			ExpectedError: "no parameters for cab model Champion 600 in the gear catalog or the presets of the profile; use -force",
+		},
+		{
+			Name:    "Set model with unsupported path",
+			Command: "setg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"AmpA.Slot0",
+				"Overdrive",
+			},
+			ExpectedError: "invalid or unsupported path AmpA.Slot0",
+		},
+		{
+			Name:    "Set amp gain relatively with wildcards",
+			Command: "sg",
+			Args: []string{
+				"-r",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Amplitube"),
+				"AmpA.Amp.Gain_*-=10%",
+			},
+			CustomAssertion: func(workingDir string) error {
+				expected := map[string]string{
+					"American Tube Clean 1.at5p":                 "4.5",
+					filepath.Join("Metal", "Metal Clean T.at5p"): "4.85156",
+					filepath.Join("SVX", "SVX-4B.at5p"):          "4.5",
+				}
+				for file, gain := range expected {
+					preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Amplitube", file))
+					if err != nil {
+						return err
+					}
+					attrs := preset.child("AmpA").child("Amp").matchAttrs("Gain_*")
+					if len(attrs) != 1 || attrs[0].Value != gain {
+						return errors.New(file + " gain not " + gain)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set attribute with arithmetic and clamping",
+			Command: "sg",
+			Args: []string{
+				"-min", "0",
+				"-max", "10",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"AmpA.Amp.*+=8",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				amp := preset.child("AmpA").child("Amp")
+				if amp.attr("Gain_AmericanTubeClean") != "10" || amp.attr("Reverb_AmericanTubeClean") != "9.25" {
+					return errors.New("amp not changed: " + amp.attr("Gain_AmericanTubeClean") + " " + amp.attr("Reverb_AmericanTubeClean"))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set attribute by multiplying",
+			Command: "sg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"Studio.DI_Level*=2",
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, err := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if err != nil {
+					return err
+				}
+				if value := preset.child("Studio").attr("DI_Level"); value != "-6" {
+					return errors.New("DI_Level not doubled: " + value)
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Set non-numeric attribute with arithmetic",
+			Command: "sg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"CabA.Cab.RoomType+=1",
+			},
+			ExpectedError: "RoomType: value Large Studio is not a number",
+		},
+		{
+			Name:    "Set attribute wildcard without matches",
+			Command: "sg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+				"AmpA.Amp.Unknown_*-=1",
+			},
+			ExpectedError: "no attributes match AmpA.Amp.Unknown_*",
+		},
+		{
+			Name:    "Lint valid preset",
+			Command: "lint",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+		},
+		{
+			Name:    "Lint valid Amplitube 4 preset",
+			Command: "lint",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Default.at4p"),
+			},
+			CustomSetup: setupV4Preset,
+		},
+		{
+			Name:    "Lint invalid preset",
+			Command: "lint",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p")
+				preset, _ := readPresetFile(file)
+				stomps := preset.child("StompA1")
+				stomps.setAttr("Stomp2", "e11b1dc5-1f7d-42ad-af30-0539b3646b3c")
+				stomps.removeChild("Slot2")
+				stomps.setAttr("Stomp3", "12345678-1234-1234-1234-123456789012")
+				stomps.putAttr("Stomp6", EmptySlotGUID)
+				preset.child("AmpA").child("Amp").setAttr("Gain_AmericanTubeClean", "12")
+				writePresetFile(file, preset)
+			},
+			Expected:      "Default.at5p: error: Preset.StompA1.Slot2: missing slot element for Delay",
+			ExpectedError: "4 issue(s) found",
+		},
+		{
+			Name:    "Lint parameters against the gear catalog",
+			Command: "lint",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				os.MkdirAll(filepath.Dir(userCatalogFile()), 0775)
+				ioutil.WriteFile(userCatalogFile(), []byte(`{"amps": [{"guid": "f058124b-498f-4899-8b29-35453d6aecff", "name": "Bi-Valve", "parameters": [{"name": "Gain_THDBiValve", "min": 0, "max": 8}]}]}`), 0664)
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD", "BiValve.at5p")
+				preset, _ := readPresetFile(file)
+				preset.child("AmpA").child("Amp").setAttr("Gain_THDBiValve", "9")
+				writePresetFile(file, preset)
+			},
+			Expected:      "BiValve.at5p: warning: Preset.AmpA.Amp.Gain_THDBiValve: value 9 out of range 0 to 8",
+			ExpectedError: "1 issue(s) found",
+			CustomAssertion: func(workingDir string) error {
+				return os.Remove(userCatalogFile())
+			},
+		},
+		{
+			Name:    "Search gear catalog",
+			Command: "catalog",
+			Args: []string{
+				"search",
+				"tape echo",
+			},
+			Expected: `fx:
+    907ecdf1-15be-4f41-b56d-2705e7bb89ae  EP Tape Echo  (Pedals/Delay)`,
+		},
+		{
+			Name:    "List unknown gear kind",
+			Command: "catalog",
+			Args: []string{
+				"list",
+				"pedals",
+			},
+			ExpectedError: "unknown gear kind pedals",
+		},
+		{
+			Name:    "Add to profile gear catalog",
+			Command: "catalog",
+			Args: []string{
+				"-p", TestDataRoot,
+				"-category", "Drive",
+				"add",
+				"fx",
+				"a1000000-0000-0000-0000-000000000000",
+				"Test Drive",
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, AmptFolder, CatalogFile),
+			},
+			CustomAssertion: func(workingDir string) error {
+				defer loadCatalogs("")
+				if err := loadCatalogs(workingDir); err != nil {
+					return err
+				}
+				if FX["a1000000-0000-0000-0000-000000000000"] != "Test Drive" {
+					return errors.New("catalog entry not added")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "List gear with profile catalog",
+			Command: "lsg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSource"+PresetExtension),
+			},
+			CustomSetup: func(workingDirs []string) {
+				os.MkdirAll(filepath.Join(workingDirs[0], AmptFolder), 0775)
+				ioutil.WriteFile(filepath.Join(workingDirs[0], AmptFolder, CatalogFile), []byte(`{"amps": [{"guid": "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", "name": "Test Amp"}]}`), 0664)
+			},
+			Expected: "AmpA: Test Amp",
+		},
+		{
+			Name:    "Report unknown gear",
+			Command: "unknown",
+			Args: []string{
+				"-o", filepath.Join(TestDataRoot, "unknown.json"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+			},
+			Expected: `amp aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa in 4 preset(s)
+    TestGearEmpty.at5p
+    TestGearSource.at5p
+    TestGearSparseSource.at5p
+`,
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, "unknown.json"),
+			},
+			CustomAssertion: func(workingDir string) error {
+				stub, err := readCatalogFile(filepath.Join(workingDir, "unknown.json"))
+				if err != nil {
+					return err
+				}
+				if len(stub.Amps) != 3 || stub.Amps[0].GUID != "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa" || stub.Amps[2].GUID != "cccccccc-cccc-cccc-cccc-cccccccccccc" || len(stub.FX) == 0 {
+					return errors.New(fmt.Sprint("unexpected stub catalog ", stub.Amps))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Report unknown gear outside of the chain",
+			Command: "unknown",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				file := filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD", "BiValve.at5p")
+				preset, _ := readPresetFile(file)
+				preset.child("StompB3").setAttr("Stomp4", "12345678-1234-1234-1234-123456789012")
+				writePresetFile(file, preset)
+			},
+			Expected: `fx 12345678-1234-1234-1234-123456789012 in 1 preset(s)
+    BiValve.at5p`,
+		},
+		{
+			Name:    "Report no unknown gear",
+			Command: "unknown",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+			},
+			Expected: "no unknown gear found",
+		},
+		{
+			Name:    "List presets as JSON",
+			Command: "ls",
+			Args: []string{
+				"--json",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDirs[0], "Presets.db"))
+				defer database.Close()
+				database.Exec("update pXcPresets set Rating = 4 where OriginalFileName = ?", filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+			},
+			CustomAssertion: func(workingDir string) error {
+				var listings []PresetListing
+				if err := json.Unmarshal(out.(*bytes.Buffer).Bytes(), &listings); err != nil {
+					return err
+				}
+				if len(listings) != 1 || listings[0].Name != "BiValve" || listings[0].Kind != "preset" || listings[0].Format != "at5p" {
+					return errors.New(fmt.Sprint("unexpected listing ", listings))
+				}
+				if listings[0].Meta["KeyWords"] != "BiValve Head" || listings[0].Meta["Rating"] != "4" {
+					return errors.New(fmt.Sprint("unexpected metadata ", listings[0].Meta))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "List presets as CSV",
+			Command: "ls",
+			Args: []string{
+				"-r",
+				"--csv",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+			},
+			Expected: "Folder,Name,Kind,Path,Format,Description,Style,SoundCharacter,Instrument,Body,PickUpPosition,Type,Artist,Band,Song,SongStructureElement,KeyWords,Favorite,Rating,MadeWith\n",
+			CustomAssertion: func(workingDir string) error {
+				expected := filepath.Join("Amps", "THD") + ",BiValve,preset," + filepath.Join(workingDir, PresetsFolder, "Amps", "THD", "BiValve.at5p") + ",at5p,"
+				if !strings.Contains(out.(*bytes.Buffer).String(), expected) {
+					return errors.New("BiValve not listed")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "List gear as JSON",
+			Command: "lsg",
+			Args: []string{
+				"--json",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			CustomAssertion: func(workingDir string) error {
+				var listing GearListing
+				if err := json.Unmarshal(out.(*bytes.Buffer).Bytes(), &listing); err != nil {
+					return err
+				}
+				for _, block := range listing.Blocks {
+					if block.Block == "AmpA" && (block.Model != "American Tube Clean 1" || block.Category != "Clean" || !block.Active || block.Parameters.value("Gain_AmericanTubeClean") != "5") {
+						return errors.New(fmt.Sprint("unexpected amp ", block))
+					}
+					if block.Block == "CabA" && (len(block.Parts) == 0 || block.Parts[0].Model != "American 12C") {
+						return errors.New(fmt.Sprint("unexpected cab ", block))
+					}
+				}
+				if listing.Chain != "Chain11" || len(listing.Blocks) == 0 {
+					return errors.New(fmt.Sprint("unexpected listing ", listing))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Stats of a folder",
+			Command: "stats",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+			},
+			Expected: "amp      Bi-Valve             Crunch      1",
+		},
+		{
+			Name:    "Stats as JSON",
+			Command: "stats",
+			Args: []string{
+				"--json",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDirs[0], "Presets.db"))
+				defer database.Close()
+				database.Exec("update pXcPresets set Rating = 4 where OriginalFileName = ?", filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+			},
+			CustomAssertion: func(workingDir string) error {
+				var library LibraryStats
+				if err := json.Unmarshal(out.(*bytes.Buffer).Bytes(), &library); err != nil {
+					return err
+				}
+				if library.Presets != 9 || len(library.Chains) != 1 || library.Chains[0] != (StatCount{Name: "Chain11", Count: 9}) {
+					return errors.New(fmt.Sprint("unexpected stats ", library))
+				}
+				if len(library.Ratings) != 2 || library.Ratings[1] != (StatCount{Name: "4", Count: 1}) {
+					return errors.New(fmt.Sprint("unexpected ratings ", library.Ratings))
+				}
+				for _, category := range library.Categories {
+					if category.Type == "amp" && category.Category == "Clean" && category.Count != 3 {
+						return errors.New(fmt.Sprint("unexpected categories ", library.Categories))
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name:          "Stats of an empty folder",
+			Command:       "stats",
+			Args:          []string{filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Empty")},
+			ExpectedError: "no presets found",
+		},
+		{
+			Name:    "Find identical presets",
+			Command: "dupes",
+			Args: []string{
+				"-t", "1",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+			},
+			Expected: `identical:
+    TestGearSparseSource.at5p
+    TestGearSparseSource2.at5p`,
+		},
+		{
+			Name:    "Find similar presets",
+			Command: "dupes",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder),
+			},
+			Expected: "similar:",
+		},
+		{
+			Name:    "No duplicate presets",
+			Command: "dupes",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+			},
+			Expected: "no duplicates found",
+		},
+		{
+			Name:    "Invalid similarity threshold",
+			Command: "dupes",
+			Args: []string{
+				"-t", "2",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+			},
+			ExpectedError: "similarity threshold must be greater than 0 and at most 1",
+		},
+		{
+			Name:    "Remove duplicate presets",
+			Command: "dupes",
+			Args: []string{
+				"-t", "1",
+				"-rm",
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Amplitube", "American Tube Clean 1"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource"+PresetExtension),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource2"+PresetExtension),
+			},
+			ExpectDBNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default"+PresetExtension),
+			},
+		},
+		{
+			Name:    "Move duplicate presets",
+			Command: "dupes",
+			Args: []string{
+				"-t", "1",
+				"-mv", filepath.Join(TestDataRoot, PresetsFolder, "Dupes"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps"),
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Dupes", "Default"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Dupes", "TestGearSparseSource2"+PresetExtension),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default"+PresetExtension),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Dupes", "Default"+PresetExtension),
+			},
+		},
+		{
+			Name:    "Create snapshot",
+			Command: "snapshot",
+			Args: []string{
+				"-m", "before cleanup",
+				"create",
+				filepath.Join(TestDataRoot),
+			},
+			Expected: "created snapshot 1 of",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, AmptFolder, SnapshotFolder, "000001.json"),
+			},
+		},
+		{
+			Name:    "Create snapshot of unchanged profile",
+			Command: "snapshot",
+			Args: []string{
+				"create",
+				filepath.Join(TestDataRoot, PresetsFolder),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("snapshot", []string{"create", workingDirs[0]})
+			},
+			Expected: "file(s), 0 new",
+		},
+		{
+			Name:    "List snapshots",
+			Command: "snapshot",
+			Args: []string{
+				"list",
+				filepath.Join(TestDataRoot),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("snapshot", []string{"-m", "before cleanup", "create", workingDirs[0]})
+			},
+			Expected: "file(s)  before cleanup",
+		},
+		{
+			Name:    "Restore snapshot",
+			Command: "snapshot",
+			Args: []string{
+				"restore",
+				filepath.Join(TestDataRoot),
+				"1",
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("snapshot", []string{"create", workingDirs[0]})
+				ExecuteCommand("rm", []string{"-r", filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD")})
+				ExecuteCommand("mkdir", []string{filepath.Join(workingDirs[0], PresetsFolder, "Added")})
+			},
+			Expected: "cleared 2 operation(s) from the history",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, AmptFolder, SnapshotFolder, "000002.json"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Empty"),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Added"),
+				filepath.Join(TestDataRoot, AmptFolder, JournalFolder),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension),
+			},
+		},
+		{
+			Name:    "Restore missing snapshot",
+			Command: "snapshot",
+			Args: []string{
+				"restore",
+				filepath.Join(TestDataRoot),
+				"3",
+			},
+			ExpectedError: "snapshot 3 not found",
+		},
+		{
+			Name:    "Delete snapshot",
+			Command: "snapshot",
+			Args: []string{
+				"delete",
+				filepath.Join(TestDataRoot),
+				"1",
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("snapshot", []string{"create", workingDirs[0]})
+			},
+			Expected: "deleted snapshot 1",
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, AmptFolder, SnapshotFolder, "000001.json"),
+			},
+			CustomAssertion: func(workingDir string) error {
+				objects, _ := filepath.Glob(filepath.Join(workingDir, AmptFolder, SnapshotFolder, SnapshotObjectFolder, "*", "*"))
+				if len(objects) != 0 {
+					return errors.New(fmt.Sprint("objects not deleted ", objects))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Pack presets",
+			Command: "pack",
+			Args: []string{
+				"-o", filepath.Join(TestDataRoot, "share"+PackExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default"+PresetExtension),
+			},
+			Expected: "packed 2 preset(s)",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, "share"+PackExtension),
+			},
+			CustomAssertion: func(workingDir string) error {
+				reader, err := zip.OpenReader(filepath.Join(workingDir, "share"+PackExtension))
+				if err != nil {
+					return err
+				}
+				defer reader.Close()
+				var names []string
+				for _, file := range reader.File {
+					names = append(names, file.Name)
+				}
+				if strings.Join(names, ",") != "manifest.json,presets/THD/BiValve.at5p,presets/Default.at5p" {
+					return errors.New(fmt.Sprint("unexpected bundle entries ", names))
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Pack path outside of presets",
+			Command: "pack",
+			Args: []string{
+				filepath.Join(TestDataRoot),
+			},
+			ExpectedError: "is not in a presets folder",
+		},
+		{
+			Name:         "Unpack presets",
+			Command:      "unpack",
+			WorkDirCount: 2,
+			Args: []string{
+				filepath.Join(TestDataRoot+"[1]", "share"+PackExtension),
+				TestDataRoot,
+			},
+			CustomSetup: func(workingDirs []string) {
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDirs[1], "Presets.db"))
+				database.Exec("update pXcPresets set Rating = 4 where OriginalFileName = ?", filepath.Join(workingDirs[1], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+				database.Close()
+				ExecuteCommand("pack", []string{"-o", filepath.Join(workingDirs[1], "share"+PackExtension), filepath.Join(workingDirs[1], PresetsFolder, "Amps")})
+				ExecuteCommand("mkdir", []string{filepath.Join(workingDirs[0], PresetsFolder, "Import")})
+			},
+			Expected: "unpacked 9 preset(s)",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import - 1", "Amps", "THD", "BiValve"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Import - 1", "Amps", "Empty"),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import - 1", "Amps", "THD", "BiValve"+PresetExtension),
+			},
+			CustomAssertion: func(workingDir string) error {
+				file := filepath.Join(workingDir, PresetsFolder, "Import - 1", "Amps", "THD", "BiValve.at5p")
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDir, "Presets.db"))
+				defer database.Close()
+				var keywords string
+				var rating float64
+				if err := database.QueryRow("select Keywords, Rating from pXcPresets where OriginalFileName = ?", file).Scan(&keywords, &rating); err != nil {
+					return err
+				}
+				if keywords != "BiValve Head" || rating != 4 {
+					return errors.New(fmt.Sprint("unexpected record ", keywords, " ", rating))
+				}
+				unpacked, _ := readPresetFile(file)
+				original, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+				if unpacked.attr("GUID") == original.attr("GUID") {
+					return errors.New("GUID not regenerated")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Unpack invalid bundle",
+			Command: "unpack",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default"+PresetExtension),
+				TestDataRoot,
+			},
+			ExpectedError: "invalid bundle",
+		},
+		{
+			Name:         "Import subset of presets",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				TestDataRoot + "[1]",
+				TestDataRoot,
+				filepath.Join("Amps", "THD"),
+				filepath.Join("Amps", "Empty"),
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import", "Amps", "THD", "BiValve"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Import", "Amps", "Empty"),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import", "Amps", "Default"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Import", "Amps2"),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import", "Amps", "THD", "BiValve"+PresetExtension),
+			},
+		},
+		{
+			Name:         "Import into next free import folder",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				TestDataRoot + "[1]",
+				TestDataRoot,
+				filepath.Join("Amps", "THD"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				os.MkdirAll(filepath.Join(workingDirs[0], PresetsFolder, "Import"), 0775)
+				os.MkdirAll(filepath.Join(workingDirs[0], PresetsFolder, "Import - 1"), 0775)
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import - 2", "Amps", "THD", "BiValve"+PresetExtension),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import - 1 - 2"),
+			},
+		},
+		{
+			Name:         "Import presets matching filter",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-where", "amp~Bi-Valve",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import", "Amps", "THD", "BiValve"+PresetExtension),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import", "Amps", "Default"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Import", "Amps", "Empty"),
+			},
+		},
+		{
+			Name:         "Import treats arguments as paths",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				TestDataRoot + "[1]",
+				TestDataRoot,
+				"amp~Bi-Valve",
+			},
+			ExpectedError: "amp~Bi-Valve",
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import"),
+			},
+		},
+		{
+			Name:         "Import into folder skips existing presets",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-to", ".",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+				filepath.Join("Amps", "THD"),
+			},
+			Expected: "skipped " + filepath.Join("Amps", "THD", "BiValve"+PresetExtension) + "; already exists",
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import"),
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension))
+				if preset.attr("GUID") != "c7b663a3-ba55-4506-83a4-4fb571f06de2" {
+					return errors.New("existing preset was replaced")
+				}
+				return nil
+			},
+		},
+		{
+			Name:         "Import into folder overwriting existing presets",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-to", "Amps",
+				"-conflict", "overwrite",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+				filepath.Join("Amps", "THD"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("cp", []string{"-r", filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD"), filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Amps")})
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Amps", "THD", "BiValve"+PresetExtension),
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Amps", "THD", "BiValve"+PresetExtension))
+				copied, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension))
+				if preset.attr("GUID") == copied.attr("GUID") {
+					return errors.New("existing preset was not replaced")
+				}
+				return nil
+			},
+		},
+		{
+			Name:         "Import into folder renaming conflicting presets",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-to", ".",
+				"-conflict", "rename",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+				filepath.Join("Amps", "THD"),
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve - 1"+PresetExtension),
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve - 1"+PresetExtension),
+			},
+		},
+		{
+			Name:         "Import newer presets only",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-to", ".",
+				"-conflict", "newer",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+				filepath.Join("Amps", "THD"),
+				filepath.Join("Amps", "Default"+PresetExtension),
+			},
+			CustomSetup: func(workingDirs []string) {
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDirs[1], "Presets.db"))
+				defer database.Close()
+				database.Exec("update pXcPresets set tstamp = '2030-01-01 00:00:00' where OriginalFileName = ?", filepath.Join(workingDirs[1], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+				database.Exec("update pXcPresets set tstamp = '2000-01-01 00:00:00' where OriginalFileName = ?", filepath.Join(workingDirs[1], PresetsFolder, "Amps", "Default.at5p"))
+			},
+			Expected: "skipped " + filepath.Join("Amps", "Default"+PresetExtension) + "; existing preset is newer",
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension),
+			},
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension))
+				if preset.attr("GUID") == "c7b663a3-ba55-4506-83a4-4fb571f06de2" {
+					return errors.New("older preset was not replaced")
+				}
+				return nil
+			},
+		},
+		{
+			Name:         "Import newer presets than unrecorded presets",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-to", ".",
+				"-conflict", "newer",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+				filepath.Join("Amps", "THD"),
+				filepath.Join("Amps", "Default"+PresetExtension),
+			},
+			CustomSetup: func(workingDirs []string) {
+				target, _ := sql.Open("sqlite3", filepath.Join(workingDirs[0], "Presets.db"))
+				defer target.Close()
+				target.Exec("delete from pXcPresets where OriginalFileName in (?, ?)", filepath.Join(workingDirs[0], PresetsFolder, "Amps", "THD", "BiValve.at5p"), filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p"))
+				source, _ := sql.Open("sqlite3", filepath.Join(workingDirs[1], "Presets.db"))
+				defer source.Close()
+				source.Exec("update pXcPresets set tstamp = ? where OriginalFileName = ?", time.Now().UTC().Add(time.Hour).Format("2006-01-02 15:04:05"), filepath.Join(workingDirs[1], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+				source.Exec("update pXcPresets set tstamp = ? where OriginalFileName = ?", time.Now().UTC().Add(-time.Hour).Format("2006-01-02 15:04:05"), filepath.Join(workingDirs[1], PresetsFolder, "Amps", "Default.at5p"))
+			},
+			Expected: "skipped " + filepath.Join("Amps", "Default"+PresetExtension) + "; existing preset is newer",
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension),
+			},
+		},
+		{
+			Name:         "Import skips presets recorded older under another path",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-to", ".",
+				"-conflict", "newer",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+				filepath.Join("Amps", "THD"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDirs[1], "Presets.db"))
+				defer database.Close()
+				database.Exec("update pXcPresets set tstamp = '2000-01-01 00:00:00', OriginalFileName = ? where OriginalFileName = ?", filepath.Join(string(filepath.Separator)+"Moved", PresetsFolder, "Amps", "THD", "BiValve.at5p"), filepath.Join(workingDirs[1], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+			},
+			Expected: "skipped " + filepath.Join("Amps", "THD", "BiValve"+PresetExtension) + "; existing preset is newer",
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "THD", "BiValve"+PresetExtension))
+				if preset.attr("GUID") != "c7b663a3-ba55-4506-83a4-4fb571f06de2" {
+					return errors.New("newer preset was replaced")
+				}
+				return nil
+			},
+		},
+		{
+			Name:         "Import presets matching database filter recorded under another path",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-where", "db.Rating=5",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+			},
+			CustomSetup: func(workingDirs []string) {
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDirs[1], "Presets.db"))
+				defer database.Close()
+				database.Exec("update pXcPresets set Rating = 5, OriginalFileName = ? where OriginalFileName = ?", filepath.Join(string(filepath.Separator)+"Moved", PresetsFolder, "Amps", "THD", "BiValve.at5p"), filepath.Join(workingDirs[1], PresetsFolder, "Amps", "THD", "BiValve.at5p"))
+			},
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import", "Amps", "THD", "BiValve"+PresetExtension),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Import", "Amps", "Default"+PresetExtension),
+			},
+		},
+		{
+			Name:         "Failed import into folder removes created folders",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-to", "Target",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+			},
+			CustomSetup: func(workingDirs []string) {
+				os.MkdirAll(filepath.Join(workingDirs[0], PresetsFolder, "Target"), 0775)
+				ioutil.WriteFile(filepath.Join(workingDirs[0], PresetsFolder, "Target", "Amps2"), []byte{}, 0644)
+			},
+			ExpectedError: "Failed to created directories",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Target", "Amps2"),
+			},
+			ExpectNotExist: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Target", "Amps"),
+			},
+		},
+		{
+			Name:         "Import with unknown conflict policy",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-conflict", "merge",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+			},
+			ExpectedError: "unknown conflict policy merge; must be one of skip, overwrite, rename, newer",
+		},
+		{
+			Name:         "Import into folder outside of presets",
+			Command:      "import",
+			WorkDirCount: 2,
+			Args: []string{
+				"-to", "..",
+				TestDataRoot + "[1]",
+				TestDataRoot,
+			},
+			ExpectedError: "is not in the target presets folder",
+		},
+		{
+			Name:    "Move gear block to slot of itself",
+			Command: "mvg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompA1",
+				"StompA1.Slot3",
+			},
+			ExpectedError: "cannot move StompA1 within itself",
+		},
+		{
+			Name:    "Move gear block to other slot of itself",
+			Command: "mvg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSparseSource.at5p"),
+				"StompB1",
+				"StompB1.Slot2",
+			},
+			ExpectedError: "cannot move StompB1 within itself",
+		},
+		{
+			Name:    "Invalid user gear catalog",
+			Command: "catalog",
+			Args: []string{
+				"list",
+			},
+			CustomSetup: func(workingDirs []string) {
+				os.MkdirAll(filepath.Dir(userCatalogFile()), 0775)
+				ioutil.WriteFile(userCatalogFile(), []byte("{"), 0664)
+			},
+			Expected: "warning: invalid gear catalog",
+			CustomAssertion: func(workingDir string) error {
+				defer os.Remove(userCatalogFile())
+				if !strings.Contains(out.(*bytes.Buffer).String(), "EP Tape Echo") {
+					return errors.New("built in gear not listed")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Add to profile gear catalog with invalid user gear catalog",
+			Command: "catalog",
+			Args: []string{
+				"-p", TestDataRoot,
+				"add",
+				"fx",
+				"a1000000-0000-0000-0000-000000000000",
+				"Test Drive",
+			},
+			CustomSetup: func(workingDirs []string) {
+				os.MkdirAll(filepath.Dir(userCatalogFile()), 0775)
+				ioutil.WriteFile(userCatalogFile(), []byte("{"), 0664)
+			},
+			Expected: "warning: invalid gear catalog",
+			ExpectExists: []string{
+				filepath.Join(TestDataRoot, AmptFolder, CatalogFile),
+			},
+			CustomAssertion: func(workingDir string) error {
+				return os.Remove(userCatalogFile())
+			},
+		},
+		{
+			Name:    "Invalid user gear catalog stops other commands",
+			Command: "lsg",
+			Args: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				os.MkdirAll(filepath.Dir(userCatalogFile()), 0775)
+				ioutil.WriteFile(userCatalogFile(), []byte("{"), 0664)
+			},
+			ExpectedError: "invalid gear catalog",
+			CustomAssertion: func(workingDir string) error {
+				return os.Remove(userCatalogFile())
+			},
+		},
+		{
+			Name:    "Import document over existing preset",
+			Command: "import-doc",
+			Args: []string{
+				filepath.Join(TestDataRoot, "TestGearSource.yaml"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("export", []string{"-format", "yaml", "-o", filepath.Join(workingDirs[0], "TestGearSource.yaml"), filepath.Join(workingDirs[0], PresetsFolder, "Amps", "TestGearSource.at5p")})
+			},
+			ExpectedError: "already exists; use -force to replace it",
+			CustomAssertion: func(workingDir string) error {
+				preset, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if gear := presetGear(preset); gear[0].Name != "American Tube Clean 1" {
+					return errors.New("existing preset was replaced")
+				}
+				return nil
+			},
+		},
+		{
+			Name:    "Force import document over existing preset",
+			Command: "import-doc",
+			Args: []string{
+				"-force",
+				filepath.Join(TestDataRoot, "TestGearSource.yaml"),
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			CustomSetup: func(workingDirs []string) {
+				ExecuteCommand("export", []string{"-format", "yaml", "-o", filepath.Join(workingDirs[0], "TestGearSource.yaml"), filepath.Join(workingDirs[0], PresetsFolder, "Amps", "TestGearSource.at5p")})
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDirs[0], "Presets.db"))
+				defer database.Close()
+				database.Exec("update pXcPresets set Rating = 4 where OriginalFileName = ?", filepath.Join(workingDirs[0], PresetsFolder, "Amps", "Default.at5p"))
+			},
+			ExpectDBExists: []string{
+				filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default.at5p"),
+			},
+			CustomAssertion: func(workingDir string) error {
+				original, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "TestGearSource.at5p"))
+				imported, _ := readPresetFile(filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p"))
+				if len(diffPresets(original, imported)) != 0 {
+					return errors.New(fmt.Sprint("preset not replaced ", diffPresets(original, imported)))
+				}
+				database, _ := sql.Open("sqlite3", filepath.Join(workingDir, "Presets.db"))
+				defer database.Close()
+				var rating int
+				database.QueryRow("select Rating from pXcPresets where OriginalFileName = ?", filepath.Join(workingDir, PresetsFolder, "Amps", "Default.at5p")).Scan(&rating)
+				if rating != 4 {
+					return errors.New("rating of the replaced preset lost")
+				}
+				return nil
+			},
+		},
+		// TODO: remove orphans and add missing db records on reindex
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
 
-	statement, err := database.Prepare("update pXcPresets set OriginalFileName = ? || replace(OriginalFileName,'\\',?), FileFolder = ? || replace(FileFolder,'\\',?)")
+			workDirCount := tc.WorkDirCount
 
-	_, err = statement.Exec(filepath.Join(workingDir, PresetsFolder), string(filepath.Separator), filepath.Join(workingDir, PresetsFolder), string(filepath.Separator))
+			if workDirCount == 0 {
+				workDirCount = 1
+			}
 
-	if err != nil {
-		tx.Rollback()
-		database.Close()
-		log.Fatal("Failed setting up test: ", err)
-	}
+			var workingDirs []string
 
-	tx.Commit()
-	statement.Close()
-	database.Close()
+			for i := 0; i < workDirCount; i++ {
+				workingDir := setupData()
+				defer cleanUpData(workingDir)
+				workingDirs = append(workingDirs, workingDir)
+			}
 
-	return workingDir
-}
+			if tc.CustomSetup != nil {
+				tc.CustomSetup(workingDirs)
+			}
 
-func cleanUpData(workingDir string) {
-	os.RemoveAll(workingDir)
-}
+			var filteredArgs []string
 
-func rehomePath(path string, workingDirs []string) string {
-	match, _ := regexp.MatchString(TestDataRoot+"\\[\\d+\\]", path)
-	if match {
-		index := path[len(TestDataRoot)+1 : strings.Index(path, "]")]
-		i, _ := strconv.Atoi(index)
-		return strings.ReplaceAll(path, TestDataRoot+"["+index+"]", filepath.Base(workingDirs[i]))
-	} else {
-		return strings.ReplaceAll(path, TestDataRoot, filepath.Base(workingDirs[0]))
+			for _, v := range tc.Args {
+				filteredArgs = append(filteredArgs, rehomePath(v, workingDirs))
+			}
+
+			out = bytes.NewBuffer(nil)
+			err := ExecuteCommand(tc.Command, filteredArgs)
+
+			if tc.Verbose {
+				t.Logf("%s", out.(*bytes.Buffer).String())
+			}
+
+			if err != nil && !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Fatal(err)
+			}
+
+			if err == nil && tc.ExpectedError != "" {
+				t.Errorf("Expected error '%s'; there was none", tc.ExpectedError)
+			}
+
+			if err != nil && tc.ExpectedError == "" {
+				t.Errorf("Error was '%s'; no error was expected", err)
+			}
+
+			if !strings.Contains(out.(*bytes.Buffer).String(), tc.Expected) {
+				t.Errorf("wanted '%s'; was '%s'", tc.Expected, out.(*bytes.Buffer).String())
+			}
+
+			for _, path := range tc.ExpectExists {
+				path = rehomePath(path, workingDirs)
+				if info, _ := os.Stat(path); info == nil {
+					t.Errorf("Expected file '%s' to exist.", path)
+				}
+			}
+
+			for _, path := range tc.ExpectNotExist {
+				path = rehomePath(path, workingDirs)
+				if info, _ := os.Stat(path); info != nil {
+					t.Errorf("Expected file '%s' to not exists.", path)
+				}
+			}
+
+			database, err := sql.Open("sqlite3", strings.ReplaceAll(filepath.Join(workingDirs[0], "Presets.db"), "\\", "/"))
+
+			if err != nil {
+				log.Fatal("Failed validating result: ", err)
+			}
+
+			statement, err := database.Prepare("select count(id) from pXcPresets where OriginalFileName = ?")
+
+			if err != nil {
+				log.Fatal("Failed validating result: ", err)
+			}
+
+			for _, path := range tc.ExpectDBExists {
+				path = filepath.Join(filepath.Dir(workingDirs[0]), rehomePath(path, workingDirs))
+				rs, _ := statement.Query(path)
+				var count = -1
+				for rs.Next() {
+					rs.Scan(&count)
+				}
+				if count != 1 {
+					t.Errorf("Incorrect number of database record of %d for file '%s'; expected 1", count, path)
+				}
+			}
+
+			for _, path := range tc.ExpectDBNotExist {
+				path = filepath.Join(filepath.Dir(workingDirs[0]), rehomePath(path, workingDirs))
+				rs, _ := statement.Query(path)
+				var count = -1
+				for rs.Next() {
+					rs.Scan(&count)
+				}
+				if count > 0 {
+					t.Errorf("Incorrect number of database record of %d for file '%s'; expected 0", count, path)
+				}
+			}
+
+			if tc.CustomAssertion != nil {
+				if err = tc.CustomAssertion(workingDirs[0]); err != nil {
+					t.Error(err)
+				}
+			}
+
+			statement.Close()
+			database.Close()
+
+		})
 	}
-}
 
-type TestSlotGroup3 struct {
-	XMLName xml.Name  `xml:"Test"`
-	Slot0   TestSlot0 `xml:""`
-	Slot1   TestSlot1 `xml:""`
-	Slot2   TestSlot2 `xml:""`
 }
 
-type TestSlotGroup1and5 struct {
-	XMLName xml.Name   `xml:"Test"`
-	Slot0   TestSlot0  `xml:""`
-	Slot1   EmptySlot1 `xml:""`
-	Slot2   EmptySlot2 `xml:""`
-	Slot3   EmptySlot3 `xml:""`
-	Slot4   EmptySlot4 `xml:""`
-	Slot5   EmptySlot5 `xml:""`
-}
+func TestElementRoundTrip(t *testing.T) {
 
-type TestSlotGroup2and4 struct {
-	XMLName xml.Name   `xml:"Test"`
-	Slot0   TestSlot0  `xml:""`
-	Slot1   TestSlot1  `xml:""`
-	Slot2   EmptySlot2 `xml:""`
-	Slot3   EmptySlot3 `xml:""`
-	Slot4   EmptySlot4 `xml:""`
-	Slot5   EmptySlot5 `xml:""`
-}
+	files := []string{
+		filepath.Join(TestDataRoot, PresetsFolder, "Amps", "Default"+PresetExtension),
+		filepath.Join(TestDataRoot, PresetsFolder, "Amps", "TestGearSource"+PresetExtension),
+		filepath.Join("amp4data", PresetsFolder, "Default.at4p"),
+	}
 
-type TestSlotGroup6 struct {
-	XMLName xml.Name  `xml:"Test"`
-	Slot0   TestSlot0 `xml:""`
-	Slot1   TestSlot1 `xml:""`
-	Slot2   TestSlot2 `xml:""`
-	Slot3   TestSlot3 `xml:""`
-	Slot4   TestSlot4 `xml:""`
-	Slot5   TestSlot5 `xml:""`
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		preset, err := parsePreset(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		written, err := marshalPreset(preset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, written) {
+			t.Errorf("%s changed by reading and writing it", file)
+		}
+	}
 }
 
-type TestSlotGroup3And3 struct {
-	XMLName xml.Name   `xml:"Test"`
-	Slot0   TestSlot0  `xml:""`
-	Slot1   TestSlot1  `xml:""`
-	Slot2   TestSlot2  `xml:""`
-	Slot3   EmptySlot3 `xml:""`
-	Slot4   EmptySlot4 `xml:""`
-	Slot5   EmptySlot5 `xml:""`
-}
+func TestElementKeepsUnknownContent(t *testing.T) {
 
-type TestSlot0 struct {
-	XMLName xml.Name `xml:"Slot0"`
-	Name    string   `xml:",attr"`
-}
+	source := XMLPrefix + `<Preset Version="1" Zeta="z" Format="at5p" Alpha="a">
+    <Future Kind="x">
+        <Nested B="2" A="1" />
+    </Future>
+    <AmpA Bypass="0" Unknown="u" Model="m">
+        <Extra />
+        <Amp Gain="5" />
+    </AmpA>
+</Preset>
+`
 
-type TestSlot1 struct {
-	XMLName xml.Name `xml:"Slot1"`
-	Name    string   `xml:",attr"`
-}
+	preset, err := parsePreset([]byte(source))
+	if err != nil {
+		t.Fatal(err)
+	}
 
-type TestSlot2 struct {
-	XMLName xml.Name `xml:"Slot2"`
-	Name    string   `xml:",attr"`
-}
+	preset.child("AmpA").setAttr("Bypass", "1")
 
-type TestSlot3 struct {
-	XMLName xml.Name `xml:"Slot3"`
-	Name    string   `xml:",attr"`
-}
+	written, err := marshalPreset(preset)
+	if err != nil {
+		t.Fatal(err)
+	}
 
-type TestSlot4 struct {
-	XMLName xml.Name `xml:"Slot4"`
-	Name    string   `xml:",attr"`
-}
+	expected := strings.Replace(source, `Bypass="0"`, `Bypass="1"`, 1)
 
-type TestSlot5 struct {
-	XMLName xml.Name `xml:"Slot5"`
-	Name    string   `xml:",attr"`
+	if string(written) != expected {
+		t.Errorf("wanted '%s'; was '%s'", expected, written)
+	}
 }
 
-type EmptySlot0 struct {
-	XMLName xml.Name `xml:"Slot0"`
+func stompGUIDs(file string, block string) string {
+	preset, _ := readPresetFile(file)
+	element := preset.child(block)
+	var guids []string
+	for i := 0; element.hasAttr("Stomp" + strconv.Itoa(i)); i++ {
+		guid := element.attr("Stomp" + strconv.Itoa(i))
+		if guid == EmptySlotGUID {
+			guids = append(guids, "-")
+		} else {
+			guids = append(guids, guid[:8])
+		}
+	}
+	return strings.Join(guids, ",")
 }
 
-type EmptySlot1 struct {
-	XMLName xml.Name `xml:"Slot1"`
+func setupV4Preset(workingDirs []string) {
+	data, _ := ioutil.ReadFile(filepath.Join("amp4data", PresetsFolder, "Default.at4p"))
+	preset := strings.Replace(string(data), `Stomp0="773b8ea7-b54a-4a3c-99df-ffbbf6d29271"`, `Stomp0="e11b1dc5-1f7d-42ad-af30-0539b3646b3c"`, 1)
+	preset = strings.Replace(preset, `Rack1="d3b93507-420b-48f6-ac82-6f6f2892726c"`, `Rack1="b756e0c1-7685-4b38-bccc-b74c7febd868"`, 1)
+	ioutil.WriteFile(filepath.Join(workingDirs[0], PresetsFolder, "Default.at4p"), []byte(preset), 0664)
 }
 
-type EmptySlot2 struct {
-	XMLName xml.Name `xml:"Slot2"`
-}
+func setupData() string {
 
-type EmptySlot3 struct {
-	XMLName xml.Name `xml:"Slot3"`
-}
+	toCopy := map[string]string{}
 
-type EmptySlot4 struct {
-	XMLName xml.Name `xml:"Slot4"`
-}
+	randomUUID, _ := uuid.NewRandom()
 
-type EmptySlot5 struct {
-	XMLName xml.Name `xml:"Slot5"`
-}
+	pwd, _ := os.Getwd()
 
-func emptySlot3() Slot3 {
-	return Slot3{
-		XMLName: xml.Name{
-			Local: "Slot3",
-		},
-		Attrs: nil,
+	workingDir := filepath.Join(pwd, randomUUID.String())
+
+	filepath.Walk("testdata", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			log.Fatal("Failed setting up test: ", err)
+		}
+		source := filepath.Join(pwd, path)
+		if !info.IsDir() {
+			toCopy[source] = strings.ReplaceAll(source, string(filepath.Separator)+"testdata"+string(filepath.Separator), string(filepath.Separator)+randomUUID.String()+string(filepath.Separator))
+		}
+		return nil
+	})
+
+	for source, target := range toCopy {
+		os.MkdirAll(filepath.Dir(target), 0775)
+		data, _ := ioutil.ReadFile(source)
+		ioutil.WriteFile(target, data, 0664)
 	}
-}
 
-func emptySlot4() Slot4 {
-	return Slot4{
-		XMLName: xml.Name{
-			Local: "Slot4",
-		},
-		Attrs: nil,
+	filepath.Walk(workingDir, func(path string, info os.FileInfo, err error) error {
+		if filepath.Base(path) == EmptyPlaceholder {
+			os.Remove(path)
+		}
+		return nil
+	})
+
+	database, err := sql.Open("sqlite3", strings.ReplaceAll(filepath.Join(pwd, randomUUID.String(), "Presets.db"), "\\", "/"))
+
+	if err != nil {
+		log.Fatal("Failed setting up test: ", err)
+	}
+
+	tx, err := database.Begin()
+
+	if err != nil {
+		database.Close()
+		log.Fatal("Failed setting up test: ", err)
+	}
+
+	statement, err := database.Prepare("update pXcPresets set OriginalFileName = ? || replace(OriginalFileName,'\\',?), FileFolder = ? || replace(FileFolder,'\\',?)")
+
+	_, err = statement.Exec(filepath.Join(workingDir, PresetsFolder), string(filepath.Separator), filepath.Join(workingDir, PresetsFolder), string(filepath.Separator))
+
+	if err != nil {
+		tx.Rollback()
+		database.Close()
+		log.Fatal("Failed setting up test: ", err)
 	}
+
+	tx.Commit()
+	statement.Close()
+	database.Close()
+
+	return workingDir
 }
 
-func emptySlot5() Slot5 {
-	return Slot5{
-		XMLName: xml.Name{
-			Local: "Slot5",
-		},
-		Attrs: nil,
+func cleanUpData(workingDir string) {
+	os.RemoveAll(workingDir)
+}
+
+func rehomePath(path string, workingDirs []string) string {
+	match, _ := regexp.MatchString(TestDataRoot+"\\[\\d+\\]", path)
+	if match {
+		index := path[len(TestDataRoot)+1 : strings.Index(path, "]")]
+		i, _ := strconv.Atoi(index)
+		return strings.ReplaceAll(path, TestDataRoot+"["+index+"]", filepath.Base(workingDirs[i]))
+	} else {
+		return strings.ReplaceAll(path, TestDataRoot, filepath.Base(workingDirs[0]))
 	}
 }
@@ -0,0 +1,174 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+func catalog(context ExecutionContext) error {
+
+	if len(context.Args) < 1 {
+		return errors.New("catalog requires list, search or add")
+	}
+
+	profile := *context.Options["profile"].(*string)
+
+	if profile != "" {
+		profile, _ = filepath.Abs(profile)
+		if !isProfileFolder(profile) {
+			return errors.New("profile must be the root of an Amplitube profile")
+		}
+	}
+
+	if err := loadCatalogs(profile); err != nil {
+		fmt.Fprintln(out, "warning: "+err.Error())
+	}
+
+	switch context.Args[0] {
+	case "list":
+		kinds := CatalogKinds
+		if len(context.Args) > 1 {
+			kind, err := catalogKind(context.Args[1])
+			if err != nil {
+				return err
+			}
+			kinds = []string{kind}
+		}
+		printCatalog(kinds, "")
+	case "search":
+		if len(context.Args) < 2 {
+			return errors.New("catalog search requires text to search for")
+		}
+		if !printCatalog(CatalogKinds, context.Args[1]) {
+			fmt.Fprintln(out, "no gear found")
+		}
+	case "add":
+		if len(context.Args) < 4 {
+			return errors.New("catalog add requires a kind, a guid and a name")
+		}
+		kind, err := catalogKind(context.Args[1])
+		if err != nil {
+			return err
+		}
+		entry := CatalogEntry{
+			GUID:     context.Args[2],
+			Name:     context.Args[3],
+			Category: *context.Options["category"].(*string),
+			Speakers: *context.Options["speakers"].(*int),
+		}
+		return addCatalogEntry(context, profile, kind, entry)
+	default:
+		return errors.New("unknown catalog command " + context.Args[0])
+	}
+
+	return nil
+}
+
+func catalogKind(name string) (string, error) {
+	for _, kind := range CatalogKinds {
+		if strings.EqualFold(kind, name) || strings.EqualFold(kind, name+"s") {
+			return kind, nil
+		}
+	}
+	return "", errors.New("unknown gear kind " + name + "; must be one of " + strings.Join(CatalogKinds, ", "))
+}
+
+func printCatalog(kinds []string, text string) bool {
+
+	found := false
+	text = strings.ToLower(text)
+
+	for _, kind := range kinds {
+
+		printed := false
+
+		for _, entry := range *Catalog.entries(kind) {
+
+			if text != "" && !strings.Contains(strings.ToLower(entry.GUID+" "+entry.Name+" "+entry.Category), text) {
+				continue
+			}
+
+			if !printed {
+				fmt.Fprintln(out, kind+":")
+				printed = true
+			}
+
+			line := "    " + entry.GUID
+
+			if entry.Name != entry.GUID {
+				line += "  " + entry.Name
+			}
+
+			if entry.Category != "" {
+				line += "  (" + entry.Category + ")"
+			}
+
+			fmt.Fprintln(out, line)
+			found = true
+		}
+	}
+
+	return found
+}
+
+func addCatalogEntry(context ExecutionContext, profile string, kind string, entry CatalogEntry) error {
+
+	file := userCatalogFile()
+
+	if profile != "" {
+		file = profileCatalogFile(profile)
+	}
+
+	if file == "" {
+		return errors.New("user config folder not found; use -p to add to a profile")
+	}
+
+	var catalog GearCatalog
+
+	if isFile(file) {
+		existing, err := readCatalogFile(file)
+		if err != nil {
+			return err
+		}
+		catalog = existing
+	}
+
+	catalog.put(kind, entry)
+
+	data, err := json.MarshalIndent(catalog, "", "\t")
+
+	if err != nil {
+		return err
+	}
+
+	if err = context.mkdirAll(filepath.Dir(file), 0775); err != nil {
+		return err
+	}
+
+	if err = context.writeFile(file, append(data, '\n'), 0664); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "added "+entry.Name+" to "+file)
+
+	return nil
+}
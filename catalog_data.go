@@ -0,0 +1,477 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+// DefaultGearCatalog is the gear catalog built into ampt.  It is extended or
+// overridden by the catalog.json files of the user config and the profile.
+const DefaultGearCatalog = `{
+	"amps": [
+		{"guid": "5f4f50a1-d5cb-43be-ad11-084e4ff21ea6", "name": "'57 Champ", "category": "Clean"},
+		{"guid": "4c9e667b-932a-42e3-a5d8-a9d9374c9959", "name": "'57 Custom Twin-Amp", "category": "Clean"},
+		{"guid": "f0951b1e-91d2-4360-80d7-793fa785d2d6", "name": "'64 Vibroverb Custom", "category": "Clean"},
+		{"guid": "89b3caab-dffb-4c29-85d9-2a60cb93c566", "name": "'65 Deluxe Reverb", "category": "Clean"},
+		{"guid": "2a1f483c-a136-45b6-81ec-e92c60f8d009", "name": "'65 Princeton", "category": "Clean"},
+		{"guid": "d3c791b9-58f1-41d2-8a88-797e98cc5b29", "name": "'65 Super Reverb", "category": "Clean"},
+		{"guid": "b3869f27-a9f1-4482-add4-9512c16917ea", "name": "'65 Twin Reverb", "category": "Clean"},
+		{"guid": "c13004d2-9a19-4ae9-8c0e-9894c5e769c8", "name": "122", "category": "Clean"},
+		{"guid": "3f4b67ce-214f-445f-a0c1-b7d08f13029d", "name": "122A", "category": "Clean"},
+		{"guid": "2e94cff0-16da-445b-98bb-b8a47bc2d2da", "name": "147", "category": "Clean"},
+		{"guid": "a913acaa-80f1-4703-981c-406ec2e50874", "name": "3300W", "category": "Clean"},
+		{"guid": "a91067a3-fd80-40a8-be35-0681da5c4f47", "name": "American Clean MKIII", "category": "Clean"},
+		{"guid": "71a76a9f-cf70-4f59-971f-9864a055523c", "name": "American Tube Clean 1", "category": "Clean", "parameters": [
+			{"name": "Gain_AmericanTubeClean", "default": "5", "min": 0, "max": 10},
+			{"name": "Bass_AmericanTubeClean", "default": "5", "min": 0, "max": 10},
+			{"name": "Mid_AmericanTubeClean", "default": "5", "min": 0, "max": 10},
+			{"name": "Treble_AmericanTubeClean", "default": "5", "min": 0, "max": 10},
+			{"name": "Presence_AmericanTubeClean", "default": "5", "min": 0, "max": 10},
+			{"name": "Reverb_AmericanTubeClean", "default": "1.25", "min": 0, "max": 10},
+			{"name": "Volume_AmericanTubeClean", "default": "5", "min": 0, "max": 10}
+		]},
+		{"guid": "82972243-cd55-4b43-82f3-f15e3bc13dc7", "name": "American Tube Clean 2", "category": "Clean"},
+		{"guid": "ca4587b9-3960-49de-9509-5a61e9b5cbae", "name": "American Vintage B", "category": "Clean"},
+		{"guid": "84f03443-ae64-4c7e-970f-06d1191cd906", "name": "American Vintage D", "category": "Clean"},
+		{"guid": "95b9bc84-89fa-48f5-a336-26a30a044ca3", "name": "American Vintage T", "category": "Clean"},
+		{"guid": "016a8c2a-489e-49da-81d7-5b72feb60f74", "name": "Champion 600", "category": "Clean"},
+		{"guid": "48503c68-f5e4-40d6-a4e1-75b0168e5e6f", "name": "Custom Solid State Clean", "category": "Clean"},
+		{"guid": "6c4fc562-aa92-4e39-b73e-85773fd6a97a", "name": "G37/St12", "category": "Clean"},
+		{"guid": "ac08939a-32bf-496c-96ac-5d6c530abf14", "name": "Jazz Amp 120", "category": "Clean"},
+		{"guid": "300ed819-d21b-4589-b095-afd038e9f08c", "name": "JH 1200", "category": "Clean"},
+		{"guid": "abdcae70-bff2-4b02-bf2f-d716dd8e8adf", "name": "MAZ 18 Jr", "category": "Clean"},
+		{"guid": "15761216-f2fe-4d41-a6ec-9bff8199517c", "name": "Metal Clean T", "category": "Clean", "parameters": [
+			{"name": "Gain_MetalCleanT", "default": "5", "min": 0, "max": 10},
+			{"name": "Bass_MetalCleanT", "default": "5", "min": 0, "max": 10},
+			{"name": "Mid_MetalCleanT", "default": "5", "min": 0, "max": 10},
+			{"name": "Treble_MetalCleanT", "default": "5", "min": 0, "max": 10},
+			{"name": "Presence_MetalCleanT", "default": "5", "min": 0, "max": 10},
+			{"name": "Reverb_MetalCleanT", "default": "1.25", "min": 0, "max": 10},
+			{"name": "Volume_MetalCleanT", "default": "5", "min": 0, "max": 10}
+		]},
+		{"guid": "d0546d04-505c-42b1-8e9e-668a16adcfa8", "name": "Pro Junior", "category": "Clean"},
+		{"guid": "a2f18e96-4d56-4372-b438-11bd0f42f6f3", "name": "SilverTwelve", "category": "Clean"},
+		{"guid": "dffa559d-7b12-464a-9fbf-877ca25f5cf3", "name": "Vibro-King", "category": "Clean"},
+		{"guid": "f2190a68-52ea-408a-9c39-2ea8279c0d43", "name": "'53 Bassman", "category": "Crunch"},
+		{"guid": "6f1c22b5-3593-4d86-a9a3-fae8c9504d77", "name": "'57 Bandmaster", "category": "Crunch"},
+		{"guid": "a0fa7c56-0772-4ddd-9320-c2ee254a3c4a", "name": "'57 Custom Champ", "category": "Crunch"},
+		{"guid": "bf860ad9-cd8a-425b-8049-29211fce237a", "name": "'57 Custom Deluxe", "category": "Crunch"},
+		{"guid": "6c421302-9602-4ee8-b94a-672aa24cdde4", "name": "'57 Custom Pro-Amp", "category": "Crunch"},
+		{"guid": "d4d5b530-0ce1-46cf-a47e-bf0224fa715e", "name": "'57 Deluxe", "category": "Crunch"},
+		{"guid": "3fcc8ad1-6d5e-416d-9c3d-7aae91c6f4d4", "name": "'59 Bassman LTD", "category": "Crunch"},
+		{"guid": "dd7b0e06-a17a-4851-83c4-ee32ca303b01", "name": "AD 30", "category": "Crunch"},
+		{"guid": "0d4c8b80-92d6-4f40-8178-51ba0179eb1d", "name": "American Tube Vintage", "category": "Crunch"},
+		{"guid": "f058124b-498f-4899-8b29-35453d6aecff", "name": "Bi-Valve", "category": "Crunch", "parameters": [
+			{"name": "Gain_THDBiValve", "default": "5", "min": 0, "max": 10},
+			{"name": "Bass_THDBiValve", "default": "5", "min": 0, "max": 10},
+			{"name": "Mid_THDBiValve", "default": "5", "min": 0, "max": 10},
+			{"name": "Treble_THDBiValve", "default": "5", "min": 0, "max": 10},
+			{"name": "Presence_THDBiValve", "default": "5", "min": 0, "max": 10},
+			{"name": "Reverb_THDBiValve", "default": "1.25", "min": 0, "max": 10},
+			{"name": "Volume_THDBiValve", "default": "5", "min": 0, "max": 10}
+		]},
+		{"guid": "e3d7fcaa-742f-421c-902e-5f04c0290b96", "name": "BM 30", "category": "Crunch"},
+		{"guid": "ebecb740-4f64-4a7e-97b7-0b733e7e55da", "name": "BM DK", "category": "Crunch"},
+		{"guid": "533d3c6c-b3cd-455c-a3a1-642016f5cda9", "name": "British Blue Tube 30TB", "category": "Crunch"},
+		{"guid": "5d235e0d-9fd7-429e-b483-6f815281f3d7", "name": "British Copper 30TB", "category": "Crunch"},
+		{"guid": "d089ef66-b5c4-4274-910c-6a6ee194cf04", "name": "British Lead S100", "category": "Crunch"},
+		{"guid": "2a95b351-ba28-473d-b0a7-fd924f32d9f9", "name": "Custom Solid State Fuzz", "category": "Crunch"},
+		{"guid": "3c25674f-a418-4fec-863c-f94495c746a0", "name": "Dual Terror", "category": "Crunch"},
+		{"guid": "26fbbf20-f88e-46de-a76f-5aabd2c8fd8d", "name": "HiAmp", "category": "Crunch"},
+		{"guid": "7788f707-4ef2-44cd-862a-a82ffdf7172b", "name": "JH Gold", "category": "Crunch"},
+		{"guid": "827aedfb-cdc1-412e-8e47-5bac3c3c6d06", "name": "OR 50", "category": "Crunch"},
+		{"guid": "6e8690b3-f6cf-4c36-b3c2-7f38fcc5706e", "name": "OR-120", "category": "Crunch"},
+		{"guid": "e1eed2cf-6777-46c4-ada2-65df0d7afc46", "name": "Red Pig", "category": "Crunch"},
+		{"guid": "f4b89ab3-8ca6-44ee-b90b-a570040c8a3d", "name": "Super-Sonic", "category": "Crunch"},
+		{"guid": "99e446c7-49df-45b1-bff9-26d95e10c763", "name": "Tiny Terror", "category": "Crunch"},
+		{"guid": "1284c9cc-6efa-4720-a0da-106a2d2af1d8", "name": "TransAtlantic TA-30", "category": "Crunch"},
+		{"guid": "bd903ed7-82bf-41cc-9834-685b6e3667b5", "name": "Tube Vintage Combo", "category": "Crunch"},
+		{"guid": "cc59b472-2a2f-40b1-97f4-6ee4b7536c87", "name": "Z Wreck", "category": "Crunch"},
+		{"guid": "2ea3ecfb-1b0c-417a-8788-86f5915f43c5", "name": "AFD 100", "category": "High Gain"},
+		{"guid": "4af9d89a-c06b-4c8f-b137-af72bc58fded", "name": "American Lead MkIII", "category": "High Gain"},
+		{"guid": "8fe96936-5178-4950-9b80-d89c32534bad", "name": "Brit 8000", "category": "High Gain", "parameters": [
+			{"name": "Sensitivity_JCM800AT4", "default": "1", "min": 0, "max": 10},
+			{"name": "Presence_JCM800AT4", "default": "5", "min": 0, "max": 10},
+			{"name": "Bass_JCM800AT4", "default": "4", "min": 0, "max": 10},
+			{"name": "Middle_JCM800AT4", "default": "5", "min": 0, "max": 10},
+			{"name": "Treble_JCM800AT4", "default": "6", "min": 0, "max": 10},
+			{"name": "Master_JCM800AT4", "default": "5.5", "min": 0, "max": 10},
+			{"name": "PreAmp_JCM800AT4", "default": "5", "min": 0, "max": 10}
+		]},
+		{"guid": "cbf3c00f-dc31-4c7f-a409-f7fdbca005a8", "name": "Brit 9000", "category": "High Gain"},
+		{"guid": "3930eb8b-3eda-4079-b86d-7bfd7d4449bc", "name": "Brit Silver", "category": "High Gain"},
+		{"guid": "f970b981-527b-4eb8-ab92-fee301d74678", "name": "Brit Valve Pre", "category": "High Gain"},
+		{"guid": "fb5fc82f-a926-4591-87d2-168906fd79d3", "name": "British Tube Lead 1", "category": "High Gain"},
+		{"guid": "12db8dc3-fbda-478d-98f8-64ce892478d5", "name": "British Tube Lead 2", "category": "High Gain"},
+		{"guid": "cd657b5a-7cc1-4934-b296-58789188b662", "name": "Custom Modern Hi-Gain", "category": "High Gain"},
+		{"guid": "2ed045ad-a344-4b35-b95e-0d3a3c1220ce", "name": "Custom Solid State Lead", "category": "High Gain"},
+		{"guid": "75ad4a0e-5c75-443d-8617-9681c4fe58d3", "name": "Dual Rectifier", "category": "High Gain"},
+		{"guid": "c936fc9c-1594-48c8-b561-824827452a66", "name": "E650", "category": "High Gain"},
+		{"guid": "55078333-dcfd-41ac-9e87-cd6ea334507a", "name": "JCA20H", "category": "High Gain"},
+		{"guid": "913db945-c3a9-4e96-ad17-9c8d1053d913", "name": "JCA100H", "category": "High Gain"},
+		{"guid": "155f0121-a2ee-4e16-aaa0-44948f9be44f", "name": "JCM Slash", "category": "High Gain"},
+		{"guid": "6ec4bf7a-dc59-4443-b2fb-1e645bf5192c", "name": "Mark III", "category": "High Gain"},
+		{"guid": "1fbf7d6e-dad8-470f-b204-4d96b5466893", "name": "Mark IV", "category": "High Gain"},
+		{"guid": "9400d18f-5f72-40ac-aa37-861ba3f18da5", "name": "Metal Lead T", "category": "High Gain"},
+		{"guid": "dcc7c825-76f4-4703-8e1f-b8a12b30b1de", "name": "Metal Lead V", "category": "High Gain"},
+		{"guid": "802af8da-63c6-4ccf-a4b9-6d13255ef57f", "name": "Metal Lead W", "category": "High Gain"},
+		{"guid": "907be0ce-a419-4281-901f-dcd6763de54a", "name": "MH-500 Metalhead", "category": "High Gain"},
+		{"guid": "c81fb9d5-defb-4c65-ba59-205b9b9ea21e", "name": "MiniPlex 20", "category": "High Gain"},
+		{"guid": "bca11751-a7c1-49f5-846d-031f7eb780f0", "name": "Modern Tube Lead", "category": "High Gain"},
+		{"guid": "88d927a0-e399-4a1d-ac68-0699eee85f02", "name": "Powerball", "category": "High Gain"},
+		{"guid": "e6151532-1028-422c-9a5d-fc57594ce8e8", "name": "RockerVerb 50", "category": "High Gain"},
+		{"guid": "f24511a1-8ade-4f93-b781-ade541f0a921", "name": "SilverPlate 50", "category": "High Gain"},
+		{"guid": "4a22ac9f-aabb-4180-b697-5d5710a1acc2", "name": "SLO 100", "category": "High Gain"},
+		{"guid": "e3260631-d81f-4c76-9e4f-d12be6ede5cb", "name": "Thunderverb 200", "category": "High Gain"},
+		{"guid": "c85e5dc4-d051-4aad-846f-038b0b5233c5", "name": "Triple Rectifier", "category": "High Gain"},
+		{"guid": "5558e374-6d37-4674-a05e-2e005830d24e", "name": "V3M", "category": "High Gain"},
+		{"guid": "185e9cde-535b-42ab-abd1-4fbdb52d4808", "name": "VHandcraft 4", "category": "High Gain"},
+		{"guid": "1b5961b1-f862-4c8a-9a9b-a920da8c5cc2", "name": "Vintage Metal Lead", "category": "High Gain"},
+		{"guid": "ecb60014-617c-4637-9435-28c1480a0e8f", "name": "360Bass Preamp", "category": "Bass"},
+		{"guid": "dfb00647-6603-4fe1-a67a-5690a4dad0fb", "name": "AD 200", "category": "Bass"},
+		{"guid": "9e6f407a-161d-433b-bddc-8565103fc9ce", "name": "Bassman 300", "category": "Bass"},
+		{"guid": "9d71083c-4f67-4e3d-9a1a-77431a6d1c10", "name": "Green BA250", "category": "Bass"},
+		{"guid": "d33c157e-aa68-4cba-a9cb-4e2cff5c3caf", "name": "MB 150 S", "category": "Bass"},
+		{"guid": "18f9d728-018e-4e22-9121-b7f411b2bb77", "name": "New York B750", "category": "Bass"},
+		{"guid": "ad4ea282-ced9-49d0-9670-e9782ce5c5b7", "name": "Solid State Bass Preamp", "category": "Bass"},
+		{"guid": "0265b273-d648-47c7-a5ef-579acba82a0a", "name": "SVX-4B", "category": "Bass", "parameters": [
+			{"name": "UltraLo_AmpegV4B", "default": "0", "min": 0, "max": 10},
+			{"name": "MidRange_AmpegV4B", "default": "1", "min": 0, "max": 10},
+			{"name": "UltraHi_AmpegV4B", "default": "0", "min": 0, "max": 10},
+			{"name": "Gain_AmpegV4B", "default": "5", "min": 0, "max": 10},
+			{"name": "Bass_AmpegV4B", "default": "5", "min": 0, "max": 10},
+			{"name": "MidRangeValue_AmpegV4B", "default": "5", "min": 0, "max": 10},
+			{"name": "Treble_AmpegV4B", "default": "5", "min": 0, "max": 10},
+			{"name": "Master_AmpegV4B", "default": "5", "min": 0, "max": 10}
+		]},
+		{"guid": "41f3868c-62c3-4bd0-8c29-130e9426d4e9", "name": "SVX-15N", "category": "Bass"},
+		{"guid": "ff274db4-43c3-4fb9-b44d-d04aefd13b28", "name": "SVX-15R", "category": "Bass"},
+		{"guid": "f1d8d4c0-770c-469e-88fc-0fa2ffe7e8bc", "name": "SVX-500", "category": "Bass"},
+		{"guid": "52f28b23-80e3-4f43-9508-4447258b11c0", "name": "SVX-CL", "category": "Bass"},
+		{"guid": "862b5977-9c12-4665-88cd-86f668da8877", "name": "SVX-Pro", "category": "Bass"},
+		{"guid": "2aa0f50f-a6c9-4edd-97c2-df71a24087db", "name": "SVX-VR", "category": "Bass"},
+		{"guid": "131366d6-a73d-43bb-8357-d1f7b78b79a5", "name": "TBP-1", "category": "Bass"}
+	],
+	"cabs": [
+		{"guid": "2a7c02cf-d725-4168-9d9c-b804a3cf0ffb", "name": "1X6 BM DK", "category": "1x6"},
+		{"guid": "4b4c561b-68d7-4311-ae31-2432817850bd", "name": "Champion 600", "category": "1x6", "speakers": 1},
+		{"guid": "36ca806c-5136-4370-baa4-ce45b3c1c9af", "name": "1x8 '57 Custom Champ", "category": "1x8", "speakers": 1},
+		{"guid": "f7f9e974-28f8-4303-b177-39e620c60e9e", "name": "'57 Champ", "category": "1x8", "speakers": 1},
+		{"guid": "223ef7e4-1afe-4c89-b707-362f0c100d04", "name": "'65 Princeton", "category": "1x10", "speakers": 1},
+		{"guid": "06834dae-1774-4a8a-9ff0-e8a1f05b4ae5", "name": "Pro Junior", "category": "1x10", "speakers": 1},
+		{"guid": "7efbb008-6942-4538-875b-d65fc7321617", "name": "1x12 '57 Custom Deluxe", "category": "1x12", "speakers": 1},
+		{"guid": "72b72d5c-a977-4921-8ed9-d342ddfe964d", "name": "1X12 BM 30 Blue", "category": "1x12"},
+		{"guid": "85d85251-4cb2-4ade-b04c-9c6e150e41d7", "name": "1X12 BM 30 H70", "category": "1x12"},
+		{"guid": "9644a358-408c-4b1e-9948-806e19e076f3", "name": "1x12 Combo", "category": "1x12", "speakers": 1},
+		{"guid": "82409e48-b67c-4762-8256-963f43240ccc", "name": "1x12 Mark III", "category": "1x12", "speakers": 1},
+		{"guid": "8c6c0893-9f50-492c-ac1d-4773fd697638", "name": "1x12 Mark IV", "category": "1x12", "speakers": 1},
+		{"guid": "c895007f-963a-499c-bd76-7cb9fbf31a96", "name": "1x12 MAZ 18 Jr", "category": "1x12", "speakers": 1},
+		{"guid": "6252f4e8-9ab0-4283-aa5f-aca9f09309ff", "name": "1x12 MB 150 S", "category": "1x12", "speakers": 1},
+		{"guid": "736a416f-dc96-4781-9ef3-5559fd2e7d17", "name": "1x12 MB II", "category": "1x12", "speakers": 1},
+		{"guid": "46e8ee66-4ff8-44c6-945d-3a2dfe1323e9", "name": "1x12 MB III", "category": "1x12", "speakers": 1},
+		{"guid": "7b8ece5b-4912-4b3d-ad88-05e43245d7fc", "name": "1x12 Open Modern", "category": "1x12", "speakers": 1},
+		{"guid": "bcec521d-c918-4af6-98be-b50b644ac3dd", "name": "1x12 Open Vintage", "category": "1x12", "speakers": 1},
+		{"guid": "e49d0a85-bcde-4d98-801c-24166a47c8a1", "name": "1x12 PPC 112", "category": "1x12", "speakers": 1},
+		{"guid": "33238dfe-a58a-4b2c-a5a7-23388006e414", "name": "1x12 Tiny Terror", "category": "1x12", "speakers": 1},
+		{"guid": "70b17311-7b73-4415-968e-c26543e22e18", "name": "'57 Deluxe", "category": "1x12", "speakers": 1},
+		{"guid": "57aa5488-221b-4f3f-bb80-bdf0083efe52", "name": "'65 Deluxe Reverb", "category": "1x12", "speakers": 1},
+		{"guid": "67012b6a-0886-41d3-9a03-daa13ed47c34", "name": "Super-Sonic", "category": "1x12", "speakers": 1},
+		{"guid": "55ca6af1-4c70-44e8-957e-4f07cc6861b9", "name": "1x15  Bass Vintage", "category": "1x15", "speakers": 1},
+		{"guid": "0c2f1129-e09d-4510-9624-7c8b05f188cf", "name": "1x15  OBC 115", "category": "1x15", "speakers": 1},
+		{"guid": "151ace02-771b-44d1-afea-0ca3056c3b2c", "name": "1x15 '53 Bassman", "category": "1x15", "speakers": 1},
+		{"guid": "59f2b03d-40c1-405b-aeea-38df617b49fa", "name": "1x15 '57 Custom Pro-Amp", "category": "1x15", "speakers": 1},
+		{"guid": "7a1b419a-fbd7-4507-93e3-d6dba20ea7ad", "name": "'64 Vibroverb Custom", "category": "1x15", "speakers": 1},
+		{"guid": "4da81432-6ff0-4ed4-9df0-119b19ea8681", "name": "SVX-15N", "category": "1x15", "speakers": 1},
+		{"guid": "291a5adb-cc71-4eda-be0d-5a0af6bea973", "name": "SVX-15R", "category": "1x15", "speakers": 1},
+		{"guid": "8f3296c5-9056-4fe5-b9c6-d0d0db113080", "name": "1x18 Horn Bass", "category": "1x18", "speakers": 1},
+		{"guid": "63387c79-e174-4d78-9d5e-86fdaa8bc45e", "name": "SVX-500", "category": "2x10", "speakers": 2},
+		{"guid": "2af64b91-de12-48cf-a90e-2e5c4bcc9530", "name": "2x12 '57 Custom Twin-Amp", "category": "2x12", "speakers": 2},
+		{"guid": "1d1c171f-7380-4727-8bd6-c63021db9dd6", "name": "2x12 AD 30", "category": "2x12", "speakers": 2},
+		{"guid": "8e98245b-fd88-48b4-8951-2d6171c717ce", "name": "2x12 Closed Vintage", "category": "2x12", "speakers": 2},
+		{"guid": "3c9256bb-9b75-4dd2-8d49-c9236eedfcb9", "name": "2x12 Gry British Vint", "category": "2x12", "speakers": 2},
+		{"guid": "f7902634-12e9-4a2d-9f9a-bcd22781cdab", "name": "2x12 JP Jazz", "category": "2x12", "speakers": 2},
+		{"guid": "3e1e42ab-294b-4816-953e-ef800bfdbbe6", "name": "2x12 Open SL", "category": "2x12", "speakers": 2},
+		{"guid": "afe74c01-d70d-47ba-bfd5-4ac43ecaa954", "name": "2x12 Open T J120", "category": "2x12", "speakers": 2},
+		{"guid": "d9083a81-5a8b-4b19-990e-4d933bed5067", "name": "2x12 Open Vintage", "category": "2x12", "speakers": 2},
+		{"guid": "16b2e136-c230-4598-9931-5b913efb76e6", "name": "2x12 PPC 212", "category": "2x12", "speakers": 2},
+		{"guid": "f5e43052-b042-4f7c-b49d-a96c6571a4ce", "name": "2x12 PPC OB", "category": "2x12", "speakers": 2},
+		{"guid": "d004a94e-14b6-4e92-80eb-89aa306f0008", "name": "2x12 Recifier Horizontal", "category": "2x12", "speakers": 2},
+		{"guid": "356da432-3493-4403-ac2d-761a28855638", "name": "2x12 TransAtlantic TA-30", "category": "2x12", "speakers": 2},
+		{"guid": "b1de33d6-c660-4bf6-b290-79626a35149e", "name": "2x12 V3M", "category": "2x12", "speakers": 2},
+		{"guid": "bce514e5-5fa0-40c3-ae1d-85093a3d62f6", "name": "2x12 Z Wreck", "category": "2x12", "speakers": 2},
+		{"guid": "8b37839c-5798-4584-8aca-b7bfce4819a6", "name": "'65 Twin Reverb", "category": "2x12", "speakers": 2},
+		{"guid": "a56735e4-226b-4887-bbb7-9a04fa080c0f", "name": "SVX-212 AV", "category": "2x12", "speakers": 2},
+		{"guid": "6ac86da3-7341-4a2f-b9c4-ecd118c559c4", "name": "SVX-212H", "category": "2x12", "speakers": 2},
+		{"guid": "17d311d6-440e-4d5b-a695-f74d55e986dd", "name": "2x15 Closed B J130", "category": "2x15", "speakers": 2},
+		{"guid": "a55c9112-55bf-4fc2-845d-fd4ce59eb40c", "name": "2x15 Closed D J130", "category": "2x15", "speakers": 2},
+		{"guid": "21a4eb35-fce4-482a-9199-ace3f4205ede", "name": "3x10 '57 Bandmaster", "category": "3x10", "speakers": 3},
+		{"guid": "104346d1-5fbc-474d-bcf0-7980cbe04a82", "name": "Vibro-King", "category": "3x10", "speakers": 3},
+		{"guid": "fa7d0a6a-23e9-4f05-a062-607f5cfe7dda", "name": "4x10 '65 Super Reverb", "category": "4x10", "speakers": 4},
+		{"guid": "c4af1d27-afd0-426f-9c96-e2f65378b93a", "name": "4x10 OBC 410", "category": "4x10", "speakers": 4},
+		{"guid": "9fa8c924-6543-4085-b55b-58b99aada17e", "name": "4x10 Open Vintage", "category": "4x10", "speakers": 4},
+		{"guid": "6ea64fbe-8fd5-4eac-a216-28cb0f07faa3", "name": "4x10+tw Bass", "category": "4x10", "speakers": 4},
+		{"guid": "3b999e93-6120-4a91-b3d7-0f2902be747f", "name": "4x10+tw TE Bass", "category": "4x10", "speakers": 4},
+		{"guid": "4614704e-7ca2-4736-a750-648fe9033650", "name": "'59 Bassman", "category": "4x10", "speakers": 4},
+		{"guid": "fad46e0e-3760-484f-9f7b-85f0376780ef", "name": "SVX-410B", "category": "4x10", "speakers": 4},
+		{"guid": "1052d32a-7bd7-4463-98c0-b9cc18a000be", "name": "SVX-410S", "category": "4x10", "speakers": 4},
+		{"guid": "936efc52-2172-4faf-9be5-e8f45244a2b9", "name": "4x12 1960BV SL", "category": "4x12", "speakers": 4},
+		{"guid": "c97bc69c-c02d-4cce-b19d-859b72833550", "name": "4x12 1960AV SL", "category": "4x12", "speakers": 4},
+		{"guid": "cd231131-d053-4193-bd88-7bbd14144680", "name": "4x12 Brit 30", "category": "4x12", "speakers": 4},
+		{"guid": "7c0b8ce1-cbb4-4e5b-9973-a572143ddb2b", "name": "4x12 Brit 8000", "category": "4x12", "speakers": 4},
+		{"guid": "a54d97da-cd7f-4742-acba-45cb2688c8c9", "name": "4x12 Brit 9000", "category": "4x12", "speakers": 4},
+		{"guid": "c6dc5147-0436-482f-9a8d-070ccea23c46", "name": "4x12 Brit Silver", "category": "4x12", "speakers": 4},
+		{"guid": "79818fe5-e89f-437b-86bc-de80ecea216d", "name": "4x12 Closed 25 C", "category": "4x12", "speakers": 4},
+		{"guid": "c4ea21cc-6444-4779-9eee-62d4bc085410", "name": "4x12 Closed 75 C", "category": "4x12", "speakers": 4},
+		{"guid": "9ef10de4-2781-4ab7-9179-c1cc4b6615e5", "name": "4x12 Closed HiAmp", "category": "4x12", "speakers": 4},
+		{"guid": "0f086cfd-b793-4b69-894a-b69d4c32154b", "name": "4x12 Closed J120", "category": "4x12", "speakers": 4},
+		{"guid": "67f95a0d-34e8-4206-b321-3e57c8d1b407", "name": "4x12 Closed Modern", "category": "4x12", "speakers": 4},
+		{"guid": "445c0a64-c729-4502-b7c4-91e211a7fc21", "name": "4x12 Closed Vintage", "category": "4x12", "speakers": 4},
+		{"guid": "8a9bd7a7-d080-4023-9a60-e8cfcddccf0e", "name": "4x12 Metal F 1", "category": "4x12", "speakers": 4},
+		{"guid": "8b147712-d44d-4564-a40f-fe288110ea6c", "name": "4x12 Metal T 1", "category": "4x12", "speakers": 4},
+		{"guid": "2bca68bf-1cbd-4b37-b6c2-1b40e1902e5c", "name": "4x12 Metal V 1", "category": "4x12", "speakers": 4},
+		{"guid": "ff9cad13-059d-46bf-815a-d8f851c410d4", "name": "4x12 Modern M 1", "category": "4x12", "speakers": 4},
+		{"guid": "4edd00f5-1dc0-4130-a3bb-7eb608e834ab", "name": "4x12 PPC 412", "category": "4x12", "speakers": 4},
+		{"guid": "849b3340-9e28-411f-9faf-e99b7b2bfb36", "name": "4x12 Recto Traditional Slant", "category": "4x12", "speakers": 4},
+		{"guid": "6dfb576f-b549-4dd4-ac79-1021bcb53bb2", "name": "4x12 Red Pig", "category": "4x12", "speakers": 4},
+		{"guid": "81866d8a-1072-4bcd-adce-861f842f4e5c", "name": "4x12 Vintage M 1", "category": "4x12", "speakers": 4},
+		{"guid": "8eaae0e6-4c9b-471e-814b-6a4596a4d153", "name": "E 412 PRO XXL", "category": "4x12", "speakers": 4},
+		{"guid": "088fe7ff-34f6-4a64-9a7c-0f938f05553d", "name": "E 412 Standard", "category": "4x12", "speakers": 4},
+		{"guid": "bd7bff67-d9c8-472a-95f7-54ba61e1441c", "name": "MH-412SL", "category": "4x12"},
+		{"guid": "fb54d283-dfc2-402e-8c76-87713387d770", "name": "8x10  OBC 810", "category": "8x10", "speakers": 8},
+		{"guid": "b3900806-4600-4cc0-a2f2-217b9ec09c5f", "name": "Bass 810 Pro", "category": "8x10", "speakers": 8},
+		{"guid": "b31f4357-3c42-4c89-8736-64f25bcbed9d", "name": "SVX-810 AV", "category": "8x10", "speakers": 8},
+		{"guid": "b11418d6-9a01-42a9-a85b-cd287ac7a98e", "name": "SVX-810E", "category": "8x10", "speakers": 8},
+		{"guid": "846d771b-3037-4d47-90c7-30e33e32af6f", "name": "122", "category": "Other", "speakers": 4},
+		{"guid": "09baf8f5-28ab-4ff9-aa9e-871bde32e4b7", "name": "122A", "category": "Other"},
+		{"guid": "9edd8e4c-c515-4f9e-8009-54b742bb2ab2", "name": "147", "category": "Other"},
+		{"guid": "8af34f84-74ea-456e-b930-ba51d4d439c4", "name": "3300W", "category": "Other"},
+		{"guid": "800668a2-d6b6-4a4b-9cf7-f599c54ca364", "name": "G37", "category": "Other"},
+		{"guid": "6ea5141d-9c8d-4ecd-be53-c472a1d7af41", "name": "Studio 12", "category": "Other"},
+		{"guid": "c275ee33-8180-4b0c-9215-dd6f37aa2394", "name": "Vibratone", "category": "Other", "speakers": 4}
+	],
+	"speakers": [
+		{"guid": "a3cc18b8e9b449e3b1ce34c69b310b83", "name": "American 12C"},
+		{"guid": "d2b5f9c3e33d442ab14cf65d84aed0f5", "name": "American 12K"},
+		{"guid": "02079eab6ff44741961cd95bb82b9662", "name": "American Alnico"},
+		{"guid": "0b4e1019fe2d42c7b2292a29d4194543", "name": "American Bulldog"},
+		{"guid": "e372dd04b11d49588c290fbe341e97ca", "name": "Brit 75"},
+		{"guid": "942153d281fb4b089fc20e07a34e9ca7", "name": "Brit 80"},
+		{"guid": "d9c445a5002341f191b0c066d4a45eb3", "name": "Brit 100"},
+		{"guid": "aa7f635a7c284116a6229675340f9fd8", "name": "Brit Alnico B"},
+		{"guid": "96d52a2264b8495bb0a5c2571deb498f", "name": "Brit Alnico G"},
+		{"guid": "492ec44546cb43798742ddd231cf632a", "name": "Brit Alnico S"},
+		{"guid": "674b563d948e4f3398d18f8904096315", "name": "Brit Anniversary 1"},
+		{"guid": "7f26988d1b424e83b12587238b83c623", "name": "Brit Anniversary 2"},
+		{"guid": "7b2ac1f3a2f1478babce98766d5e2cd8", "name": "Brit Darkness"},
+		{"guid": "a56188a9a6bc4373903dbbde779548f1", "name": "Brit Green"},
+		{"guid": "93ece316161d4a7db5c075a64a873b02", "name": "Brit Silver"},
+		{"guid": "fc5bcd9eedca47b786e18803eb284b9c", "name": "Brit T12G"},
+		{"guid": "5eca5662178a403885f7caea56cef141", "name": "Brit V1"},
+		{"guid": "2dc1a3c46a204deba9cd5e939ae1e1fa", "name": "Brit V2"},
+		{"guid": "8c9127bce65e47f1bfd6c873fdbe822d", "name": "Brit V3"},
+		{"guid": "1a8ca2dad6434218b82dbf98921c0a9b", "name": "Brit Vintage 8"},
+		{"guid": "b413c57dca9541778646330ee16375c5", "name": "Brit Vintage 16A"},
+		{"guid": "9422a3d95e6b4c63bc6db15fcbd99f09", "name": "Brit VIntage 16B"},
+		{"guid": "8b9fc1cef0124429b728f0e822a1329e", "name": "California Red"},
+		{"guid": "91e0a91609a74704b7739023e3bda8d8", "name": "Custom Fender"},
+		{"guid": "d61186a940d948d1889194a5e6dcfc6b", "name": "CV GT12-16"},
+		{"guid": "4c176b93da64461bb9894d042c5475fc", "name": "EV Darkness"},
+		{"guid": "d052f84ca5fd4a699d4bd4fa68c155f2", "name": "HiAmp"},
+		{"guid": "f755dce5b3004aae8b07adac9da35705", "name": "Jazz 12"},
+		{"guid": "a5cad4f1d3b144ceaf6f6b4c2094cddc", "name": "Metal V 1200"},
+		{"guid": "a13a9305422c4f2f893fa58dcdca4f2e", "name": "Silver Alnico"}
+	],
+	"mics": [
+		{"guid": "1425abc1-2525-4d85-bfbf-f40009c2f19c", "name": "Bottle 563", "category": "Condensers"},
+		{"guid": "035c9475-312b-4f6e-87b4-c33aad7d5470", "name": "Condenser 12", "category": "Condensers"},
+		{"guid": "9f8a2c8c-aa21-43ab-a316-5084479de02e", "name": "Condenser 67", "category": "Condensers"},
+		{"guid": "2b667232-0a83-4132-a18b-f51e71fa349c", "name": "Condenser 84", "category": "Condensers"},
+		{"guid": "9e444286-cab4-46a4-bfa3-a6d55b3ffcfb", "name": "Condenser 87", "category": "Condensers"},
+		{"guid": "d78598e7-3fa4-46da-aadc-5a7733b7f896", "name": "Condenser 170", "category": "Condensers"},
+		{"guid": "0f35a776-f6db-403d-930f-6b7f42fed749", "name": "Condenser 414", "category": "Condensers"},
+		{"guid": "8e0525ab-e522-41d3-870e-9da851c42167", "name": "MD1-b", "category": "Condensers"},
+		{"guid": "333890d1-62de-4f2a-a4c3-1ca0dd0d9196", "name": "Tube VM", "category": "Condensers"},
+		{"guid": "eb1d233a-8aec-4708-b42b-b4fd26397889", "name": "Dynamic 20", "category": "Dynamic"},
+		{"guid": "1e41acc4-85af-4e84-bee4-eabc0be5fef1", "name": "Dynamic 57", "category": "Dynamic"},
+		{"guid": "b216abec-6fae-4fcd-95fd-c89aacf60ee2", "name": "Dynamic 421", "category": "Dynamic"},
+		{"guid": "c8fce7b6-deaf-461c-8628-5cfe82c15173", "name": "Dynamic 441", "category": "Dynamic"},
+		{"guid": "373859a6-cfc1-4c2c-ab8c-35ddbfb8ee77", "name": "Dynamic 609", "category": "Dynamic"},
+		{"guid": "565a6dcf-89df-4190-a552-c76d78bdab66", "name": "Vintage Dynamic 20", "category": "Dynamic"},
+		{"guid": "Condenser 12", "name": "Condenser 12", "category": "Overheads"},
+		{"guid": "Condenser 87", "name": "Condenser 87", "category": "Overheads"},
+		{"guid": "Condenser 170", "name": "Condenser 170", "category": "Overheads"},
+		{"guid": "Condenser 414", "name": "Condenser 414", "category": "Overheads"},
+		{"guid": "cf06582b-4b26-42ce-9491-e00e7ab2481e", "name": "Ribbon 121", "category": "Ribbon"},
+		{"guid": "f1869200-4515-4ab5-a690-096d142e548d", "name": "Ribbon 160", "category": "Ribbon"},
+		{"guid": "1cb1f17b-bc70-485d-bd8a-339e54eedac5", "name": "Velo-8", "category": "Ribbon"}
+	],
+	"rooms": [
+		{"guid": "Amp Closet", "name": "Amp Closet"},
+		{"guid": "Bathroom", "name": "Bathroom"},
+		{"guid": "Garage", "name": "Garage"},
+		{"guid": "Hall", "name": "Hall"},
+		{"guid": "Large Studio", "name": "Large Studio"},
+		{"guid": "Mid Studio", "name": "Mid Studio"},
+		{"guid": "Small Studio", "name": "Small Studio"},
+		{"guid": "Subway", "name": "Subway"}
+	],
+	"fx": [
+		{"guid": "b756e0c1-7685-4b38-bccc-b74c7febd868", "name": "Analog Delay", "category": "Pedals/Delay"},
+		{"guid": "e11b1dc5-1f7d-42ad-af30-0539b3646b3c", "name": "Delay", "category": "Pedals/Delay"},
+		{"guid": "48e7b721-d57a-4c34-813b-95d8091d5eda", "name": "EchoMan", "category": "Pedals/Delay"},
+		{"guid": "907ecdf1-15be-4f41-b56d-2705e7bb89ae", "name": "EP Tape Echo", "category": "Pedals/Delay"},
+		{"guid": "bf72ebc2-a539-4cd2-9204-2d91e9d573df", "name": "Replica", "category": "Pedals/Delay"},
+		{"guid": "4468f4f7-0068-4b8b-ac2b-99e13113fe2d", "name": "Slash Delay", "category": "Pedals/Delay"},
+		{"guid": "28bb2c33-0bdf-44f7-9274-2eca934cbbff", "name": "SSTE", "category": "Pedals/Delay"},
+		{"guid": "96b57f95-4380-444a-8c0a-fbcc9bef1dd9", "name": "TapDelay", "category": "Pedals/Delay"},
+		{"guid": "8bbfc5b9-bf29-4a55-8211-ca21dcfda8bf", "name": "Tape Echo", "category": "Pedals/Delay"},
+		{"guid": "d36a32bf-200c-4906-93b9-0aa91cd1f579", "name": "AmpLess", "category": "Pedals/Distortion"},
+		{"guid": "58dbec22-58e0-464c-8c04-91fb9d9973e2", "name": "BigPig", "category": "Pedals/Distortion"},
+		{"guid": "305c9b6b-04cf-4673-b58a-e62afb4fefcb", "name": "Crusher", "category": "Pedals/Distortion"},
+		{"guid": "5e65abef-82eb-4995-b911-d5eca4f8291e", "name": "Diode Overdrive", "category": "Pedals/Distortion"},
+		{"guid": "510f6d25-6ec4-417b-bf58-0f8028209cce", "name": "Distortion", "category": "Pedals/Distortion"},
+		{"guid": "395ed825-f3e8-40c1-8d69-34d8b23c9100", "name": "Feedback", "category": "Pedals/Distortion"},
+		{"guid": "e5c8acd3-3771-4df9-8d2e-ee33c8dd3d21", "name": "Metal Distortion 2", "category": "Pedals/Distortion"},
+		{"guid": "1910832b-2b47-46ff-b14c-46ec168e50e6", "name": "Metal Distortion", "category": "Pedals/Distortion"},
+		{"guid": "1d03a910-c5a3-461e-a43a-485ddf3d84ef", "name": "Moller", "category": "Pedals/Distortion"},
+		{"guid": "e5644c95-e382-4cfe-9c1f-85451017771d", "name": "Mudhoney", "category": "Pedals/Distortion"},
+		{"guid": "7c499158-084f-49b1-9543-f7e9acc122e0", "name": "OCD", "category": "Pedals/Distortion"},
+		{"guid": "967e57ac-b67d-4b97-942e-aca407e306e0", "name": "OctoBlue", "category": "Pedals/Distortion"},
+		{"guid": "fd627f5e-ba11-4082-b546-a4f0b05985ff", "name": "Overdrive", "category": "Pedals/Distortion"},
+		{"guid": "fa1de2e2-102b-4edf-b3b5-23ceaeddedf0", "name": "Overscream", "category": "Pedals/Distortion"},
+		{"guid": "8a96f6a6-49af-41fb-ab36-a62a18f17def", "name": "Pinnacle Deluxe", "category": "Pedals/Distortion"},
+		{"guid": "16daf2e6-1c56-4abe-97c9-1fffe2b22bb2", "name": "Power Grid", "category": "Pedals/Distortion"},
+		{"guid": "9b672f82-2832-4134-8db7-5cb9147c69a3", "name": "PRODrive", "category": "Pedals/Distortion"},
+		{"guid": "1d665fde-1a62-42a1-be6d-bad9bbe5df3d", "name": "SVX-OD", "category": "Pedals/Distortion"},
+		{"guid": "c8b142b0-4480-4d79-bc5c-f0232440ce05", "name": "The Ambass'dor", "category": "Pedals/Distortion"},
+		{"guid": "dbeca376-df39-45c1-b63e-3ee55b747b00", "name": "VariDiode+", "category": "Pedals/Distortion"},
+		{"guid": "77f0f320-cc4e-44be-9ffe-2f0b679434ae", "name": "Booster", "category": "Pedals/Dynamics"},
+		{"guid": "5478981b-b18a-469f-81e7-a3e228cc9d50", "name": "Compressor", "category": "Pedals/Dynamics"},
+		{"guid": "26c75920-d4bf-4e5e-900f-f78c70e06c17", "name": "Dcomp", "category": "Pedals/Dynamics"},
+		{"guid": "f5edced9-6dfc-4851-8651-f81f5423d210", "name": "Fender Compressor", "category": "Pedals/Dynamics"},
+		{"guid": "d3e05ec0-2c7b-498a-adc0-b263e853ad30", "name": "Gate", "category": "Pedals/Dynamics"},
+		{"guid": "0455f997-43ca-4c9b-9269-286a19d10d48", "name": "Noise Gate", "category": "Pedals/Dynamics"},
+		{"guid": "97c9c8d9-2f26-4126-98f7-64fbc60765ca", "name": "Red Special", "category": "Pedals/Dynamics"},
+		{"guid": "8a24aa96-f0ae-4e1c-a534-6671e245a690", "name": "SVX Compressor", "category": "Pedals/Dynamics"},
+		{"guid": "382fd7fe-b60f-440b-aed8-3dae6e9e94c6", "name": "Treble Booster", "category": "Pedals/Dynamics"},
+		{"guid": "8d7ff76e-9273-46b6-95d5-3d7bd667fff2", "name": "7 Band Graphic", "category": "Pedals/EQ"},
+		{"guid": "babadeaf-9c28-4641-8fa9-d7366a3238a2", "name": "10 Band Graphic", "category": "Pedals/EQ"},
+		{"guid": "15b140e0-3e02-4adc-a9c4-c652960e60f9", "name": "Bass Envelope Filter", "category": "Pedals/Filter"},
+		{"guid": "01cadfae-3ced-4ea6-8676-29a7e6c920b2", "name": "Bass Wah", "category": "Pedals/Filter"},
+		{"guid": "487cd1a4-834e-45b2-b5be-6a424cc6a123", "name": "Contour Wah", "category": "Pedals/Filter"},
+		{"guid": "77a321dd-69e1-4474-be07-d8a97e78bd1f", "name": "Envelope Filter", "category": "Pedals/Filter"},
+		{"guid": "75f96017-8a09-41fd-9979-75bf8bf81645", "name": "Fender Wah", "category": "Pedals/Filter"},
+		{"guid": "a58d91b0-d7c5-4d3d-8a9a-5c8b75335502", "name": "Fuzz Wah", "category": "Pedals/Filter"},
+		{"guid": "390c602d-5834-417d-bf0c-cafe544c5869", "name": "LFO Filter", "category": "Pedals/Filter"},
+		{"guid": "23c22c20-42ec-472f-84cf-2ae6b20f6f3b", "name": "May Wah", "category": "Pedals/Filter"},
+		{"guid": "0332d916-2ab2-4b7d-98c4-73a80a42b3b1", "name": "Nu-Tron III", "category": "Pedals/Filter"},
+		{"guid": "327d6d53-b6cb-4d33-bdaf-620fb52c20ec", "name": "Rezo", "category": "Pedals/Filter"},
+		{"guid": "590df33c-23a6-4d35-bd49-b5b589ffd248", "name": "Star Gate", "category": "Pedals/Filter"},
+		{"guid": "25425c78-31db-48f4-ad57-09f41e0e1291", "name": "Step Filter", "category": "Pedals/Filter"},
+		{"guid": "2de5239a-78d6-4a01-82e6-2ea3afb60501", "name": "Wah 10", "category": "Pedals/Filter"},
+		{"guid": "bc86a019-ffd5-4b71-8bfe-5913e3d58d7c", "name": "Wah 46", "category": "Pedals/Filter"},
+		{"guid": "02cd5797-10d8-4ffa-b4f4-438b93028941", "name": "Wah 47", "category": "Pedals/Filter"},
+		{"guid": "6482748e-9382-4ad6-b284-5c29ee50f2d7", "name": "Wah", "category": "Pedals/Filter"},
+		{"guid": "88863a3a-cfe3-4e86-b735-1303c511bf5f", "name": "WahDist", "category": "Pedals/Filter"},
+		{"guid": "8beec4ce-fb43-4f81-935a-3b5cb3695c8b", "name": "Class Fuzz", "category": "Pedals/Fuzz"},
+		{"guid": "09ac5b94-f238-4e4c-914e-ba7662f280d9", "name": "Fuzz Age 2", "category": "Pedals/Fuzz"},
+		{"guid": "6c3ff0bf-b840-47f3-83d3-66816763097f", "name": "Fuzz Age", "category": "Pedals/Fuzz"},
+		{"guid": "0679dea3-2588-4d9d-8d0d-ef3762f1f478", "name": "Fuzz One", "category": "Pedals/Fuzz"},
+		{"guid": "aa74a915-a1fe-4f54-a8a8-5297c3e09b56", "name": "Octa-V", "category": "Pedals/Fuzz"},
+		{"guid": "b0f5949f-4825-4202-92a0-c5817f493116", "name": "RightFuzz", "category": "Pedals/Fuzz"},
+		{"guid": "64e7c1cd-b860-40c7-930b-6d820b1ffa77", "name": "XS Fuzz", "category": "Pedals/Fuzz"},
+		{"guid": "ae6177c2-27c2-4463-a06a-357408bb2082", "name": "Analog Flanger", "category": "Pedals/Modulation"},
+		{"guid": "ed2c3a06-d304-496b-b031-7725a3d27eea", "name": "Bass Analog Chorus", "category": "Pedals/Modulation"},
+		{"guid": "bc6a9f33-ac11-41f8-973d-0327d4f3e018", "name": "Chorus", "category": "Pedals/Modulation"},
+		{"guid": "2a9ef349-fb29-4e66-99a9-cc66d10192cc", "name": "Chorus-1", "category": "Pedals/Modulation"},
+		{"guid": "8a878202-9126-4d20-8e73-374e178312f4", "name": "Electric Flanger", "category": "Pedals/Modulation"},
+		{"guid": "7ccf016f-e540-4e46-a124-8f19ce5ab2b1", "name": "Flanger", "category": "Pedals/Modulation"},
+		{"guid": "92605dfc-4716-49ef-944f-fd8c86d76bb2", "name": "Fox Phaser", "category": "Pedals/Modulation"},
+		{"guid": "4e4d82f9-224a-4ffb-9994-97ef8285c315", "name": "Metal Flanger", "category": "Pedals/Modulation"},
+		{"guid": "b1ad4a5d-1ad2-4b32-8532-945b869409e3", "name": "Nirvana", "category": "Pedals/Modulation"},
+		{"guid": "50378f09-a919-4dee-9bbe-c242403a52a2", "name": "Opto Tremolo", "category": "Pedals/Modulation"},
+		{"guid": "6178531f-d021-43c0-8922-858ffa085746", "name": "Phaser", "category": "Pedals/Modulation"},
+		{"guid": "a4ed5e25-707d-40ef-9846-64eeb820aeea", "name": "Phaze Nine", "category": "Pedals/Modulation"},
+		{"guid": "cc424097-15e5-47d3-abb9-3925073ac22b", "name": "Phazer10", "category": "Pedals/Modulation"},
+		{"guid": "86875e91-6fbd-4198-a45c-a06119e6a967", "name": "Seek Trem", "category": "Pedals/Modulation"},
+		{"guid": "0ba47121-179c-4d42-bbb6-c3e81bb4f7af", "name": "Seek Wah", "category": "Pedals/Modulation"},
+		{"guid": "96ae9a18-1c2b-48cc-843a-851adb43c091", "name": "Shape Shifter", "category": "Pedals/Modulation"},
+		{"guid": "0ef53d8f-2dd5-4acd-95f8-e8652ae31240", "name": "Small Phazer", "category": "Pedals/Modulation"},
+		{"guid": "187eb9ab-7ae6-4797-954b-079de09e26bb", "name": "Tremolo", "category": "Pedals/Modulation"},
+		{"guid": "a6d48956-a0e5-4d63-9c22-b5b38604d2a5", "name": "Uni-V", "category": "Pedals/Modulation"},
+		{"guid": "5f3947b1-6a09-4570-9f9c-1cc53a7fd88f", "name": "X-Chorus", "category": "Pedals/Modulation"},
+		{"guid": "ad9d0a70-7a59-4fef-ace5-c592764e3749", "name": "'63 Reverb", "category": "Pedals/Reverb"},
+		{"guid": "71fe6e6d-5879-42a7-9a31-6093ecee2a1c", "name": "Acoustic Sim", "category": "Pedals/Other"},
+		{"guid": "01776ae8-8442-4633-b5f7-6bfdaf423ccb", "name": "Fender Volume", "category": "Pedals/Other"},
+		{"guid": "66410529-1158-4d6e-a33a-474541a64571", "name": "Step Slicer", "category": "Pedals/Other"},
+		{"guid": "7b1dc197-a4ac-41cc-8b1e-d8ed4102f432", "name": "SVX Volume", "category": "Pedals/Other"},
+		{"guid": "ca453f6e-7af5-4e90-90df-ff954b17ecc2", "name": "Swell", "category": "Pedals/Other"},
+		{"guid": "de12969a-31cc-4985-b4cf-289d2970823d", "name": "Volume", "category": "Pedals/Other"},
+		{"guid": "01648ef1-6369-4170-81a3-90dd20451260", "name": "Blender", "category": "Pedals/Pitch"},
+		{"guid": "46f09ab5-ffd9-4c5b-8eec-681f880d4530", "name": "Harmonator", "category": "Pedals/Pitch"},
+		{"guid": "994770ae-ebb4-4ca8-884e-374f88fa3db0", "name": "Octav", "category": "Pedals/Pitch"},
+		{"guid": "e2b29e5c-33a0-41f0-9d54-dc749d371fe0", "name": "Pitch Shifter", "category": "Pedals/Pitch"},
+		{"guid": "9afc331b-c0c3-4592-b03f-c97f8d911e34", "name": "SVX-OCT", "category": "Pedals/Pitch"},
+		{"guid": "9b8e89e2-2959-41b2-90eb-dc5de12964d0", "name": "Wharmonator", "category": "Pedals/Pitch"},
+		{"guid": "1189979a-db5d-4dc1-9228-7bd974d8a8c5", "name": "Digital Delay", "category": "Rack Effects/Delay"},
+		{"guid": "773b8ea7-b54a-4a3c-99df-ffbbf6d29271", "name": "Tap Delay", "category": "Rack Effects/Delay"},
+		{"guid": "a8a839aa-35e1-4fac-8834-a0a1701c63d8", "name": "Tape Echo", "category": "Rack Effects/Delay"},
+		{"guid": "205ef910-f937-4d2b-a02f-a8483a3339a4", "name": "Saturator-X", "category": "Rack Effects/Distortion"},
+		{"guid": "95c36693-f913-4fc5-b60f-6b1732103cee", "name": "Tape Cassette", "category": "Rack Effects/Distortion"},
+		{"guid": "aecfbde7-4f23-44ca-9f58-b0a110f0ea7a", "name": "Black 76", "category": "Rack Effects/Dynamics"},
+		{"guid": "7307c816-856f-438b-a381-45edf43bee0b", "name": "Compressor", "category": "Rack Effects/Dynamics"},
+		{"guid": "ae881acd-227c-418e-a0b4-8463ef2b6461", "name": "Model 670", "category": "Rack Effects/Dynamics"},
+		{"guid": "d0211742-18e6-4fdb-9efa-3d72e4ae515b", "name": "Tube Compressor", "category": "Rack Effects/Dynamics"},
+		{"guid": "719106ad-5c84-4f94-a9db-eb3264281314", "name": "White 2A", "category": "Rack Effects/Dynamics"},
+		{"guid": "ec1212e3-d949-4d91-a1dd-4bb6803f8432", "name": "EQ PG", "category": "Rack Effects/EQ"},
+		{"guid": "179cdb9f-d2bf-4ee4-9172-94f2dc57a724", "name": "EQ-81", "category": "Rack Effects/EQ"},
+		{"guid": "b66b51c2-d9a3-4909-b7e0-cd1e51636e97", "name": "Graphic EQ", "category": "Rack Effects/EQ"},
+		{"guid": "9f1147a6-302f-48f3-a5bc-26cc5d399a8b", "name": "Parametric EQ 3", "category": "Rack Effects/EQ"},
+		{"guid": "7511f3f3-cac1-476f-a1da-089556f62f58", "name": "Parametric EQ", "category": "Rack Effects/EQ"},
+		{"guid": "5550afaf-263b-458b-98ef-4db90bb2f219", "name": "Vintage EQ1-A", "category": "Rack Effects/EQ"},
+		{"guid": "a7e2c155-6af8-40d5-8914-8446c46790b2", "name": "Filter Formant", "category": "Rack Effects/Filter"},
+		{"guid": "96ee1a4f-4090-4870-bd1a-1c3d908c3e63", "name": "Filter Phaser", "category": "Rack Effects/Filter"},
+		{"guid": "fa35cf20-ec32-4482-963c-87b5534a3e08", "name": "Filter-C", "category": "Rack Effects/Filter"},
+		{"guid": "f58b7298-6321-4cd9-814d-42116a056352", "name": "Filter-M", "category": "Rack Effects/Filter"},
+		{"guid": "198e9bca-4466-4bc5-be66-dfbca98c8db0", "name": "Filter-O", "category": "Rack Effects/Filter"},
+		{"guid": "1877f1c9-002a-4c05-9433-31f05c864430", "name": "Filter-R", "category": "Rack Effects/Filter"},
+		{"guid": "e2e5495c-5ac3-405f-9fdd-b73670d413c0", "name": "Rezo", "category": "Rack Effects/Filter"},
+		{"guid": "fb5d2469-05f6-4a44-9576-41ae232c9385", "name": "Step Filter", "category": "Rack Effects/Filter"},
+		{"guid": "5a6dfdc0-69d2-4e84-a84c-e500a0d75505", "name": "Wah", "category": "Rack Effects/Filter"},
+		{"guid": "02df7fb2-5418-46f2-8c80-7283a3871551", "name": "AM Modulation", "category": "Rack Effects/Modulation"},
+		{"guid": "02643125-de84-4c94-b214-4d300652332b", "name": "Analog Chorus", "category": "Rack Effects/Modulation"},
+		{"guid": "db51c05e-fc56-4347-81c4-be74dd9ec22e", "name": "Autopan", "category": "Rack Effects/Modulation"},
+		{"guid": "1edbb450-d048-11dc-95ff-0800200c9a66", "name": "Digital Chorus", "category": "Rack Effects/Modulation"},
+		{"guid": "c11388bb-6326-4766-a440-ea9fa3f82425", "name": "Digital Flanger", "category": "Rack Effects/Modulation"},
+		{"guid": "91caea60-f052-477a-b0d7-8b5520050813", "name": "FM Modulation", "category": "Rack Effects/Modulation"},
+		{"guid": "99c5d753-57e3-40a4-9612-04623ac61289", "name": "Rotary Speaker", "category": "Rack Effects/Modulation"},
+		{"guid": "9fa5b238-d7d0-47ac-a2e3-6e4e11761261", "name": "Sine Flange", "category": "Rack Effects/Modulation"},
+		{"guid": "4b91de5f-73c6-46d2-957b-6b9451abf050", "name": "Step Slicer", "category": "Rack Effects/Modulation"},
+		{"guid": "fe891a4f-6098-423d-b8dd-3213373b990c", "name": "Stereo Enhancer", "category": "Rack Effects/Modulation"},
+		{"guid": "1e27e673-20fe-474e-a438-d85a9bc566b4", "name": "Swell", "category": "Rack Effects/Modulation"},
+		{"guid": "14fd2d3b-a81d-4850-a2a6-9e94b7351059", "name": "TERC", "category": "Rack Effects/Modulation"},
+		{"guid": "cee174c4-821c-4b92-8cb4-86c38c433668", "name": "Triangle Chorus", "category": "Rack Effects/Modulation"},
+		{"guid": "647b8569-e3b4-48c3-b8a1-37c5f920e3f6", "name": "Harmonator", "category": "Rack Effects/Pitch"},
+		{"guid": "0f304b4d-65b9-4347-9f44-fcaa8509efaf", "name": "Pitch Shift", "category": "Rack Effects/Pitch"},
+		{"guid": "845b672b-255f-4edf-9e67-68b607dcf63a", "name": "Pitch Shifter", "category": "Rack Effects/Pitch"},
+		{"guid": "3c8d23d7-959a-4479-b9c2-46af9a77ba46", "name": "'63 Reverb", "category": "Rack Effects/Reverb"},
+		{"guid": "59ab0817-b168-4bdc-b837-e3cba1efb2dd", "name": "Digital Reverb", "category": "Rack Effects/Reverb"},
+		{"guid": "69dc5617-6455-4916-a0d5-a5f5138811b3", "name": "Hall Reverb", "category": "Rack Effects/Reverb"},
+		{"guid": "8996879a-e9db-4d7e-a2a7-fd6d30c07144", "name": "Inverse Reverb", "category": "Rack Effects/Reverb"},
+		{"guid": "5726816c-1af2-41f2-8427-7e045f85c95b", "name": "Plate Reverb", "category": "Rack Effects/Reverb"},
+		{"guid": "0755ca4e-ebb0-4507-a4e5-b5412667f9b2", "name": "Room Reverb", "category": "Rack Effects/Reverb"},
+		{"guid": "1520c0ae-a27f-4b36-a73f-942f9cd3e262", "name": "Shimmer Reverb", "category": "Rack Effects/Reverb"}
+	]
+}
+`
@@ -0,0 +1,211 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+)
+
+type ChainChange struct {
+	File   string
+	Preset *Element
+	From   string
+}
+
+func chain(context ExecutionContext) error {
+
+	if len(context.Args) < 2 {
+		return errors.New("chain requires a preset and a chain")
+	}
+
+	recursive := *context.Options["recursive"].(*bool)
+	mirror := *context.Options["mirror"].(*bool)
+	fold := *context.Options["fold"].(*bool)
+	force := *context.Options["force"].(*bool)
+
+	matches, err := resolveToMatches(context.Args[0], recursive, true)
+
+	if err != nil {
+		return err
+	}
+
+	target := context.Args[1]
+
+	var changes []ChainChange
+	lost := false
+
+	for _, match := range matches {
+
+		preset, err := readPresetFile(match)
+
+		if err != nil {
+			return err
+		}
+
+		schema, err := schemaForFormat(preset.attr("Format"))
+
+		if err != nil {
+			return err
+		}
+
+		if schema.Format != SchemaV5.Format {
+			return errors.New("chain not supported for " + schema.Format + " presets")
+		}
+
+		if _, ok := schema.Chains[target]; !ok {
+			return errors.New("unknown chain " + target)
+		}
+
+		chainElement := preset.child("Chain")
+
+		if chainElement == nil {
+			return errors.New("chain not found in " + match)
+		}
+
+		current := chainElement.attr("Preset")
+
+		if current == target {
+			fmt.Fprintln(out, filepath.Base(match)+": already "+target)
+			continue
+		}
+
+		warnings, err := switchChain(preset, schema, current, target, mirror, fold)
+
+		if err != nil {
+			return err
+		}
+
+		for _, warning := range warnings {
+			fmt.Fprintln(out, filepath.Base(match)+": "+warning)
+		}
+
+		lost = lost || len(warnings) > 0
+		changes = append(changes, ChainChange{File: match, Preset: preset, From: current})
+	}
+
+	if lost && !force {
+		return errors.New("active gear would be lost; use -f to change the chain anyway")
+	}
+
+	for _, change := range changes {
+		if err = context.writePreset(change.File, change.Preset); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, filepath.Base(change.File)+": "+change.From+" -> "+target)
+	}
+
+	return nil
+}
+
+// switchChain resets the blocks a chain change deactivates, optionally
+// folding their effects into the first path, and initialises or mirrors the
+// blocks it activates.  The gear that would be lost is returned.
+func switchChain(preset *Element, schema PresetSchema, from string, to string, mirror bool, fold bool) ([]string, error) {
+
+	template, err := parsePreset([]byte(PresetTemplateV5))
+
+	if err != nil {
+		return nil, err
+	}
+
+	active := map[string]bool{}
+	for _, name := range schema.Chains[from] {
+		active[name] = true
+	}
+
+	next := map[string]bool{}
+	for _, name := range schema.Chains[to] {
+		next[name] = true
+	}
+
+	var warnings []string
+
+	for _, block := range schema.chainBlocks(from) {
+
+		element := preset.child(block.Name)
+
+		if next[block.Name] || element == nil {
+			continue
+		}
+
+		var mirrorElement *Element
+		if block.Mirror != "" && next[block.Mirror] {
+			mirrorElement = preset.child(block.Mirror)
+		}
+
+		switch block.Kind {
+		case FxBlock:
+			slots := occupiedFxSlots(element, block)
+			if fold && mirrorElement != nil && len(slots) > 0 {
+				mirrorBlock, _ := schema.block(block.Mirror)
+				mirrorSlots := occupiedFxSlots(mirrorElement, mirrorBlock)
+				room := mirrorBlock.Slots - len(mirrorSlots)
+				if room < 0 {
+					room = 0
+				}
+				if room < len(slots) {
+					for _, slot := range slots[room:] {
+						warnings = append(warnings, block.Name+": "+getValueOrKey(FX, slot.GUID)+" would be lost")
+					}
+					slots = slots[:room]
+				}
+				setFxSlots(mirrorElement, mirrorBlock, append(mirrorSlots, slots...))
+			} else {
+				for _, slot := range slots {
+					warnings = append(warnings, block.Name+": "+getValueOrKey(FX, slot.GUID)+" would be lost")
+				}
+			}
+		case AmpBlock, CabBlock:
+			if mirrorElement == nil || !elementsEqual(renamed(element, block.Mirror), mirrorElement) {
+				model := getValueOrKey(Amps, element.attr("Model"))
+				if block.Kind == CabBlock {
+					model = getValueOrKey(Cabs, element.attr("CabModel"))
+				}
+				warnings = append(warnings, block.Name+": "+model+" would be lost")
+			}
+		}
+
+		if initial := template.child(block.Name); initial != nil {
+			preset.replaceChild(block.Name, initial.clone())
+		}
+	}
+
+	for _, block := range schema.chainBlocks(to) {
+
+		if active[block.Name] {
+			continue
+		}
+
+		if mirror && block.Mirror != "" && preset.child(block.Mirror) != nil {
+			preset.replaceChild(block.Name, renamed(preset.child(block.Mirror), block.Name))
+		} else if initial := template.child(block.Name); initial != nil {
+			preset.replaceChild(block.Name, initial.clone())
+		}
+	}
+
+	preset.child("Chain").putAttr("Preset", to)
+
+	return warnings, nil
+}
+
+func renamed(element *Element, name string) *Element {
+	c := element.clone()
+	c.XMLName.Local = name
+	return c
+}
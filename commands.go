@@ -21,6 +21,7 @@ import (
 	"errors"
 	"flag"
 	"path/filepath"
+	"strings"
 )
 
 type ExecutionContext struct {
@@ -28,6 +29,9 @@ type ExecutionContext struct {
 	Args     []string
 	Options  map[string]interface{}
 	Database *sql.DB
+	Tx       *sql.Tx
+	DryRun   bool
+	Journal  *Journal
 }
 
 type Runner func(ExecutionContext) error
@@ -59,7 +63,7 @@ func defaultDatabaseFactory(context ExecutionContext) (*sql.DB, error) {
 }
 
 func secondArgDatabaseFactory(context ExecutionContext) (*sql.DB, error) {
-	if len(context.Args) == 0 {
+	if len(context.Args) < 2 {
 		return nil, nil
 	}
 	source, err := filepath.Abs(context.Args[1])
@@ -80,21 +84,90 @@ func nilDatabaseFactory(context ExecutionContext) (*sql.DB, error) {
 	return nil, nil
 }
 
+// repeatedFlag collects every value of a flag given more than once.
+type repeatedFlag []string
+
+func (r *repeatedFlag) String() string {
+	return strings.Join(*r, " ")
+}
+
+func (r *repeatedFlag) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
+
+func stringsFlag(flags *flag.FlagSet, name string, usage string) *[]string {
+	values := &repeatedFlag{}
+	flags.Var(values, name, usage)
+	return (*[]string)(values)
+}
+
 func ExecuteCommand(cmd string, args []string) error {
 
 	var lsFlags = flag.NewFlagSet("ls", flag.ExitOnError)
+	var lintFlags = flag.NewFlagSet("lint", flag.ExitOnError)
 	var lsgFlags = flag.NewFlagSet("lsg", flag.ExitOnError)
+	var metaFlags = flag.NewFlagSet("meta", flag.ExitOnError)
+	var mergeFlags = flag.NewFlagSet("merge", flag.ExitOnError)
 	var mkDirFlags = flag.NewFlagSet("mkdir", flag.ExitOnError)
 	var rmFlags = flag.NewFlagSet("rm", flag.ExitOnError)
 	var rmgFlags = flag.NewFlagSet("rmg", flag.ExitOnError)
 	var mvFlags = flag.NewFlagSet("mv", flag.ExitOnError)
+	var mvgFlags = flag.NewFlagSet("mvg", flag.ExitOnError)
 	var cpFlags = flag.NewFlagSet("cp", flag.ExitOnError)
 	var cpgFlags = flag.NewFlagSet("cpg", flag.ExitOnError)
+	var catalogFlags = flag.NewFlagSet("catalog", flag.ExitOnError)
+	var chainFlags = flag.NewFlagSet("chain", flag.ExitOnError)
+	var convertFlags = flag.NewFlagSet("convert", flag.ExitOnError)
+	var diffFlags = flag.NewFlagSet("diff", flag.ExitOnError)
+	var doctorFlags = flag.NewFlagSet("doctor", flag.ExitOnError)
+	var dupesFlags = flag.NewFlagSet("dupes", flag.ExitOnError)
+	var exportFlags = flag.NewFlagSet("export", flag.ExitOnError)
+	var historyFlags = flag.NewFlagSet("history", flag.ExitOnError)
+	var findFlags = flag.NewFlagSet("find", flag.ExitOnError)
 	var importFlags = flag.NewFlagSet("import", flag.ExitOnError)
+	var importDocFlags = flag.NewFlagSet("import-doc", flag.ExitOnError)
+	var packFlags = flag.NewFlagSet("pack", flag.ExitOnError)
 	var reindexFlags = flag.NewFlagSet("reindex", flag.ExitOnError)
 	var sgFlags = flag.NewFlagSet("sg", flag.ExitOnError)
+	var setgFlags = flag.NewFlagSet("setg", flag.ExitOnError)
+	var snapshotFlags = flag.NewFlagSet("snapshot", flag.ExitOnError)
+	var statsFlags = flag.NewFlagSet("stats", flag.ExitOnError)
+	var swapFlags = flag.NewFlagSet("swap", flag.ExitOnError)
+	var undoFlags = flag.NewFlagSet("undo", flag.ExitOnError)
+	var unpackFlags = flag.NewFlagSet("unpack", flag.ExitOnError)
+	var unknownFlags = flag.NewFlagSet("unknown", flag.ExitOnError)
 
 	var commands = map[string]*Command{
+		"catalog": {
+			Flags:           catalogFlags,
+			Runner:          catalog,
+			DatabaseFactory: nilDatabaseFactory,
+			Options: map[string]interface{}{
+				"profile":  catalogFlags.String("p", "", "Use and add to the catalog of a profile"),
+				"category": catalogFlags.String("category", "", "Category of an added entry"),
+				"speakers": catalogFlags.Int("speakers", 0, "Speaker count of an added cab"),
+			},
+		},
+		"chain": {
+			Flags:           chainFlags,
+			Runner:          chain,
+			DatabaseFactory: defaultDatabaseFactory,
+			Options: map[string]interface{}{
+				"fold":      chainFlags.Bool("fold", false, "Move effects of removed blocks to the first path"),
+				"force":     chainFlags.Bool("f", false, "Change the chain even if gear is lost"),
+				"mirror":    chainFlags.Bool("m", false, "Copy the first path to added blocks"),
+				"recursive": chainFlags.Bool("r", false, "Change the chain of presets in subfolders"),
+			},
+		},
+		"convert": {
+			Flags:           convertFlags,
+			Runner:          convert,
+			DatabaseFactory: defaultDatabaseFactory,
+			Options: map[string]interface{}{
+				"recursive": convertFlags.Bool("r", false, "Convert presets in subfolders"),
+			},
+		},
 		"cp": {
 			Flags:           cpFlags,
 			Runner:          copy,
@@ -117,10 +190,71 @@ func ExecuteCommand(cmd string, args []string) error {
 				"recursive":    cpgFlags.Bool("r", false, "Copy to subfolders"),
 			},
 		},
+		"diff": {
+			Flags:           diffFlags,
+			Runner:          diff,
+			DatabaseFactory: nilDatabaseFactory,
+			Options: map[string]interface{}{
+				"json": diffFlags.Bool("j", false, "Output as JSON"),
+			},
+		},
+		"doctor": {
+			Flags:           doctorFlags,
+			Runner:          doctor,
+			DatabaseFactory: defaultDatabaseFactory,
+			Options: map[string]interface{}{
+				"fix": doctorFlags.Bool("fix", false, "Repair issues found"),
+			},
+		},
+		"dupes": {
+			Flags:           dupesFlags,
+			Runner:          dupes,
+			DatabaseFactory: defaultDatabaseFactory,
+			Options: map[string]interface{}{
+				"threshold": dupesFlags.Float64("t", DefaultSimilarity, "Similarity of near duplicates, from 0 to 1; 1 finds identical presets only"),
+				"remove":    dupesFlags.Bool("rm", false, "Remove all but the first preset of each group"),
+				"move":      dupesFlags.String("mv", "", "Move all but the first preset of each group to folder"),
+			},
+		},
+		"export": {
+			Flags:           exportFlags,
+			Runner:          export,
+			DatabaseFactory: nilDatabaseFactory,
+			Options: map[string]interface{}{
+				"format": exportFlags.String("format", "json", "Document format, json or yaml"),
+				"output": exportFlags.String("o", "", "Write document to file"),
+			},
+		},
+		"find": {
+			Flags:           findFlags,
+			Runner:          find,
+			DatabaseFactory: defaultDatabaseFactory,
+			Options: map[string]interface{}{
+				"table": findFlags.Bool("t", false, "Display results as a table"),
+			},
+		},
+		"history": {
+			Flags:           historyFlags,
+			Runner:          history,
+			DatabaseFactory: nilDatabaseFactory,
+		},
 		"import": {
 			Flags:           importFlags,
 			Runner:          importPresets,
 			DatabaseFactory: secondArgDatabaseFactory,
+			Options: map[string]interface{}{
+				"to":       importFlags.String("to", "", "Folder to import into instead of a new Import folder"),
+				"conflict": importFlags.String("conflict", "skip", "When a preset exists: skip, overwrite, rename or newer"),
+				"where":    stringsFlag(importFlags, "where", "Import only presets matching a find filter; may be repeated"),
+			},
+		},
+		"import-doc": {
+			Flags:           importDocFlags,
+			Runner:          importDocument,
+			DatabaseFactory: secondArgDatabaseFactory,
+			Options: map[string]interface{}{
+				"force": importDocFlags.Bool("force", false, "Replace an existing target preset"),
+			},
 		},
 		"ls": {
 			Flags:           lsFlags,
@@ -129,6 +263,16 @@ func ExecuteCommand(cmd string, args []string) error {
 			Options: map[string]interface{}{
 				"fullpath":  lsFlags.Bool("f", false, "Display full path"),
 				"recursive": lsFlags.Bool("r", false, "List subfolders"),
+				"json":      lsFlags.Bool("json", false, "Output presets and folders as JSON"),
+				"csv":       lsFlags.Bool("csv", false, "Output presets and folders as CSV"),
+			},
+		},
+		"lint": {
+			Flags:           lintFlags,
+			Runner:          lint,
+			DatabaseFactory: nilDatabaseFactory,
+			Options: map[string]interface{}{
+				"recursive": lintFlags.Bool("r", false, "Check presets in subfolders"),
 			},
 		},
 		"lsg": {
@@ -138,6 +282,23 @@ func ExecuteCommand(cmd string, args []string) error {
 			Options: map[string]interface{}{
 				"details": lsgFlags.Bool("d", false, "Show all details"),
 				"raw":     lsgFlags.Bool("r", false, "Display raw file"),
+				"json":    lsgFlags.Bool("json", false, "Output gear as JSON"),
+			},
+		},
+		"merge": {
+			Flags:           mergeFlags,
+			Runner:          merge,
+			DatabaseFactory: nilDatabaseFactory,
+			Options: map[string]interface{}{
+				"output": mergeFlags.String("o", "", "Write merged preset to file instead of ours"),
+			},
+		},
+		"meta": {
+			Flags:           metaFlags,
+			Runner:          meta,
+			DatabaseFactory: defaultDatabaseFactory,
+			Options: map[string]interface{}{
+				"recursive": metaFlags.Bool("r", false, "Get or set metadata in subfolders"),
 			},
 		},
 		"mkdir": {
@@ -150,6 +311,22 @@ func ExecuteCommand(cmd string, args []string) error {
 			Runner:          move,
 			DatabaseFactory: defaultDatabaseFactory,
 		},
+		"mvg": {
+			Flags:           mvgFlags,
+			Runner:          moveGear,
+			DatabaseFactory: defaultDatabaseFactory,
+			Options: map[string]interface{}{
+				"recursive": mvgFlags.Bool("r", false, "Move gear in presets in subfolders"),
+			},
+		},
+		"pack": {
+			Flags:           packFlags,
+			Runner:          pack,
+			DatabaseFactory: nilDatabaseFactory,
+			Options: map[string]interface{}{
+				"output": packFlags.String("o", "", "Bundle file to write"),
+			},
+		},
 		"reindex": {
 			Flags:           reindexFlags,
 			Runner:          reindex,
@@ -177,8 +354,69 @@ func ExecuteCommand(cmd string, args []string) error {
 			DatabaseFactory: defaultDatabaseFactory,
 			Options: map[string]interface{}{
 				"recursive": sgFlags.Bool("r", false, "Recursively set gear attribute"),
+				"min":       sgFlags.String("min", "", "Minimum value of a numeric attribute"),
+				"max":       sgFlags.String("max", "", "Maximum value of a numeric attribute"),
+			},
+		},
+		"setg": {
+			Flags:           setgFlags,
+			Runner:          setModel,
+			DatabaseFactory: defaultDatabaseFactory,
+			Options: map[string]interface{}{
+				"recursive": setgFlags.Bool("r", false, "Recursively set gear model"),
+				"force":     setgFlags.Bool("force", false, "Set gear without parameter defaults"),
+			},
+		},
+		"snapshot": {
+			Flags:           snapshotFlags,
+			Runner:          snapshot,
+			DatabaseFactory: nilDatabaseFactory,
+			Options: map[string]interface{}{
+				"message": snapshotFlags.String("m", "", "Message describing a created snapshot"),
+			},
+		},
+		"stats": {
+			Flags:           statsFlags,
+			Runner:          stats,
+			DatabaseFactory: nilDatabaseFactory,
+			Options: map[string]interface{}{
+				"json": statsFlags.Bool("json", false, "Output statistics as JSON"),
 			},
 		},
+		"swap": {
+			Flags:           swapFlags,
+			Runner:          swapGear,
+			DatabaseFactory: defaultDatabaseFactory,
+			Options: map[string]interface{}{
+				"nocabwithamp": swapFlags.Bool("c", false, "Don't swap cab with amp"),
+				"recursive":    swapFlags.Bool("r", false, "Swap gear in presets in subfolders"),
+			},
+		},
+		"undo": {
+			Flags:           undoFlags,
+			Runner:          undo,
+			DatabaseFactory: defaultDatabaseFactory,
+		},
+		"unpack": {
+			Flags:           unpackFlags,
+			Runner:          unpack,
+			DatabaseFactory: secondArgDatabaseFactory,
+		},
+		"unknown": {
+			Flags:           unknownFlags,
+			Runner:          unknownGear,
+			DatabaseFactory: nilDatabaseFactory,
+			Options: map[string]interface{}{
+				"output": unknownFlags.String("o", "", "Write a stub gear catalog of the unknown gear to file"),
+			},
+		},
+	}
+
+	dryRun := false
+
+	if (cmd == "-n" || cmd == "--dry-run") && len(args) > 0 {
+		dryRun = true
+		cmd, args = args[0], args[1:]
 	}
 
 	command := commands[cmd]
@@ -187,11 +425,24 @@ func ExecuteCommand(cmd string, args []string) error {
 		return errors.New("Unknown command " + cmd)
 	}
 
+	command.Flags.BoolVar(&dryRun, "n", dryRun, "Report changes without making them")
+	command.Flags.BoolVar(&dryRun, "dry-run", dryRun, "Report changes without making them")
+
 	command.Flags.Parse(args)
 
 	context := ExecutionContext{
 		Args:    command.Flags.Args(),
 		Options: command.Options,
+		DryRun:  dryRun,
+	}
+
+	// catalog reports a broken catalog file itself
+	if err := loadCatalogs(findProfile(context.Args)); err != nil && cmd != "catalog" {
+		return err
+	}
+
+	if !dryRun && cmd != "undo" {
+		context.Journal = newJournal(cmd, args)
 	}
 
 	database, err := command.DatabaseFactory(context)
@@ -201,10 +452,16 @@ func ExecuteCommand(cmd string, args []string) error {
 	}
 
 	if database == nil {
-		return command.Runner(context)
+		err = command.Runner(context)
 	} else {
 		context.Database = database
-		return tx(command.Runner, context)
+		err = tx(command.Runner, context)
+	}
+
+	if err != nil {
+		return err
 	}
 
+	return context.Journal.save(context.Args)
+
 }
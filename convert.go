@@ -0,0 +1,406 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ChainsV4 maps the Chain Preset index of an Amplitube 4 preset to the
+// nearest Amplitube 5 chain.  Indices not listed are converted to Chain12.
+var ChainsV4 = map[string]string{
+	"0": "Chain11",
+	"1": "Chain12",
+	"2": "Chain22",
+}
+
+var BlocksV4 = map[string]string{
+	"Tuner":   "Tuner",
+	"StompA":  "StompA1",
+	"StompB":  "StompB1",
+	"AmpA":    "AmpA",
+	"AmpB":    "AmpB",
+	"LoopFxA": "LoopFxA",
+	"LoopFxB": "LoopFxB",
+	"CabA":    "CabA",
+	"CabB":    "CabB",
+	"RackA":   "RackA",
+	"RackB":   "RackB",
+}
+
+var AmpFlexiKnobs = []string{"Gain", "Bass", "Mid", "Treble", "Presence", "Reverb", "Volume"}
+
+// Levels are linear in Amplitube 4 and in dB in Amplitube 5, so these keep
+// the Amplitube 5 defaults.
+var CabLevelAttrs = []string{"RoomLevel", "Mic0Level", "Mic1Level"}
+
+var floatValue = regexp.MustCompile(`^-?[0-9]+\.[0-9]+$`)
+
+func convert(context ExecutionContext) error {
+
+	if len(context.Args) < 1 {
+		return errors.New("convert requires an Amplitube 4 preset or folder")
+	}
+
+	recursive := *context.Options["recursive"].(*bool)
+
+	matches, err := resolveToMatches(context.Args[0], recursive, true)
+
+	if err != nil {
+		return err
+	}
+
+	target := ""
+
+	if len(context.Args) > 1 {
+		target, _ = filepath.Abs(context.Args[1])
+		if !isInPresetsFolder(target) {
+			return errors.New("presets not found on path")
+		}
+		if !isDir(target) && len(matches) > 1 {
+			return errors.New("target must be a folder when converting more than one preset")
+		}
+	}
+
+	copyRecord, err := context.prepare("insert into pXcPresets (UserId, Product, OriginalFileName, FileFolder, Favorite, Date, Name, Description, Downloads, Keywords, Song, ChainA, ChainB, Band, Artist, ATInstrumentsType, ATPickupType, ATPickupPositions, ATSoundCharacter, ATGenre, SongStructureElement, Rating, MadeWith, ChainType, tstamp, ATInstrument) select UserId, Product, ?, ?, Favorite, Date, ?, Description, Downloads, Keywords, Song, ChainA, ChainB, Band, Artist, ATInstrumentsType, ATPickupType, ATPickupPositions, ATSoundCharacter, ATGenre, SongStructureElement, Rating, MadeWith, ChainType, tstamp, ATInstrument from pXcPresets where OriginalFileName = ?")
+
+	if err != nil {
+		return errors.New("Failed preparing statement: " + err.Error())
+	}
+
+	defer copyRecord.Close()
+
+	for _, source := range matches {
+
+		if filepath.Ext(source) != PresetExtension4 {
+			if len(matches) == 1 {
+				return errors.New("not an Amplitube 4 preset")
+			}
+			continue
+		}
+
+		dest := strings.TrimSuffix(source, PresetExtension4) + PresetExtension
+
+		if isDir(target) {
+			dest = filepath.Join(target, filepath.Base(dest))
+		} else if target != "" {
+			dest = target
+		}
+
+		if isFile(dest) {
+			fmt.Fprintln(out, dest+" already exists; skipped")
+			continue
+		}
+
+		v4, err := readPresetFile(source)
+
+		if err != nil {
+			return errors.New("Could not read " + source + ": " + err.Error())
+		}
+
+		preset, issues, err := convertPreset(v4)
+
+		if err != nil {
+			return errors.New("Could not convert " + source + ": " + err.Error())
+		}
+
+		for _, issue := range issues {
+			fmt.Fprintln(out, filepath.Base(source)+": "+issue)
+		}
+
+		if err = context.writePreset(dest, preset); err != nil {
+			return errors.New("Could not write file: " + err.Error())
+		}
+
+		if context.Database == nil {
+			fmt.Fprintln(out, dest+" converted without database record")
+			continue
+		}
+
+		var count int
+
+		if err = context.QueryRow("select count(*) from pXcPresets where OriginalFileName = ?", source).Scan(&count); err != nil {
+			return errors.New("Convert failed.  Failed to update database: " + err.Error())
+		}
+
+		if count > 0 {
+			_, err = copyRecord.Exec(dest, filepath.Dir(dest), makePresetPath(filepath.Base(dest)), source)
+		} else {
+			err = insertPresetRecord(context, dest, preset)
+		}
+
+		if err != nil {
+			return errors.New("Convert failed.  Failed to update database: " + err.Error())
+		}
+
+		fmt.Fprintln(out, "converted "+dest)
+	}
+
+	return nil
+}
+
+func convertPreset(v4 *Element) (*Element, []string, error) {
+
+	if v4.attr("Format") != SchemaV4.Format {
+		return nil, nil, errors.New("not an Amplitube 4 preset")
+	}
+
+	preset, err := parsePreset([]byte(PresetTemplateV5))
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newId, _ := uuid.NewRandom()
+	preset.putAttr("GUID", newId.String())
+	preset.putAttr("PresetBPM", formatV5Value(v4.attr("PresetBPM")))
+	preset.putAttr("ProgramChange", v4.attr("ProgramChange"))
+
+	var issues []string
+
+	for _, element := range v4.Children {
+
+		name := element.XMLName.Local
+
+		switch name {
+		case "Chain":
+			chain := ChainsV4[element.attr("Preset")]
+			if chain == "" {
+				chain = "Chain12"
+				issues = append(issues, "chain preset "+element.attr("Preset")+" not mapped; using "+chain)
+			}
+			preset.child("Chain").putAttr("Preset", chain)
+		case "Input", "Output":
+			convertAttrs(element, preset.child(name))
+		case "MetaInfo":
+			metaInfo := preset.child(name)
+			for _, a := range element.Attrs {
+				metaInfo.putAttr(a.Name.Local, a.Value)
+			}
+		case "MidiAssignments":
+			if len(element.Children) > 0 {
+				issues = append(issues, "MIDI assignments not converted")
+			}
+		default:
+			targetName, ok := BlocksV4[name]
+			if !ok {
+				issues = append(issues, name+" not converted")
+				continue
+			}
+			v4Block, _ := SchemaV4.block(name)
+			v5Block, _ := SchemaV5.block(targetName)
+			target := preset.child(targetName)
+			switch v4Block.Kind {
+			case AmpBlock:
+				issues = append(issues, convertAmp(element, target)...)
+			case CabBlock:
+				issues = append(issues, convertCab(element, target)...)
+			case FxBlock:
+				issues = append(issues, convertFx(element, v4Block, target, v5Block)...)
+			default:
+				convertAttrs(element, target)
+			}
+		}
+	}
+
+	return preset, issues, nil
+}
+
+func convertAmp(v4 *Element, target *Element) []string {
+
+	var issues []string
+
+	convertAttrs(v4, target)
+
+	model := v4.attr("Model")
+	target.putAttr("Model", model)
+
+	if Amps[model] == "" {
+		issues = append(issues, target.XMLName.Local+": unknown amp "+model)
+	}
+
+	params := &Element{XMLName: target.child("Amp").XMLName}
+	dropped := false
+
+	if v4Params := v4.child("Amp"); v4Params != nil {
+		for _, a := range v4Params.Attrs {
+			name := a.Name.Local
+			if strings.Index(name, "AmpFlexi") != 0 {
+				params.putAttr(name, formatV5Value(a.Value))
+			} else if isAmpFlexiKnob(name[8:]) {
+				if parameter, ok := ampFlexiParameter(model, name[8:]); ok {
+					params.putAttr(parameter, formatV5Value(a.Value))
+				} else {
+					dropped = true
+				}
+			}
+		}
+	}
+
+	if dropped {
+		issues = append(issues, target.XMLName.Local+": parameters of "+getValueOrKey(Amps, model)+" not converted")
+	}
+
+	target.replaceChild("Amp", params)
+
+	return issues
+}
+
+func convertCab(v4 *Element, target *Element) []string {
+
+	var issues []string
+
+	convertAttrs(v4, target)
+
+	model := v4.attr("CabModel")
+	target.putAttr("CabModel", model)
+
+	if Cabs[model] == "" {
+		issues = append(issues, target.XMLName.Local+": unknown cab "+model)
+	}
+
+	speakerCount := 4
+	if SpeakerCount[model] != 0 {
+		speakerCount = SpeakerCount[model]
+	}
+
+	for i := 0; i < 4; i++ {
+		name := "SpeakerModel" + strconv.Itoa(i)
+		if !v4.hasAttr(name) {
+			continue
+		}
+		speaker := strings.ReplaceAll(v4.attr(name), "-", "")
+		target.putAttr(name, speaker)
+		if i < speakerCount && Speakers[speaker] == "" {
+			issues = append(issues, target.XMLName.Local+": unknown speaker "+v4.attr(name))
+		}
+	}
+
+	v4Params := v4.child("Cab")
+	params := target.child("Cab")
+
+	if v4Params != nil && params != nil {
+		for _, a := range v4Params.Attrs {
+			name := a.Name.Local
+			if isCabLevelAttr(name) || !params.hasAttr(name) {
+				continue
+			}
+			params.setAttr(name, formatV5Value(a.Value))
+			if (name == "Mic0Model" || name == "Mic1Model") && Mics[a.Value] == "" {
+				issues = append(issues, target.XMLName.Local+": unknown mic "+a.Value)
+			}
+		}
+	}
+
+	return issues
+}
+
+func convertFx(v4 *Element, v4Block BlockSchema, target *Element, v5Block BlockSchema) []string {
+
+	var issues []string
+
+	convertAttrs(v4, target)
+
+	slots := make([]FxSlot, v5Block.Slots)
+
+	for i, slot := range fxSlots(v4, v4Block) {
+		if isEmptyFx(slot.GUID) {
+			continue
+		}
+		location := v4Block.Name + "." + slotName(i)
+		if i >= v5Block.Slots {
+			issues = append(issues, location+" "+getValueOrKey(FX, slot.GUID)+" dropped; "+v5Block.Name+" has "+strconv.Itoa(v5Block.Slots)+" slots")
+			continue
+		}
+		if FX[slot.GUID] == "" {
+			issues = append(issues, location+": unknown effect "+slot.GUID)
+		}
+		converted := slot.Slot.clone()
+		formatV5Values(converted)
+		slots[i] = FxSlot{GUID: slot.GUID, Slot: converted}
+	}
+
+	setFxSlots(target, v5Block, slots)
+
+	return issues
+}
+
+func convertAttrs(v4 *Element, target *Element) {
+	for _, a := range v4.Attrs {
+		if target.hasAttr(a.Name.Local) {
+			target.setAttr(a.Name.Local, formatV5Value(a.Value))
+		}
+	}
+}
+
+func formatV5Values(element *Element) {
+	for i := range element.Attrs {
+		element.Attrs[i].Value = formatV5Value(element.Attrs[i].Value)
+	}
+	for _, c := range element.Children {
+		formatV5Values(c)
+	}
+}
+
+// Amplitube 4 writes every number with six decimals where Amplitube 5 uses
+// six significant digits.
+func formatV5Value(value string) string {
+	if !floatValue.MatchString(value) {
+		return value
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return value
+	}
+	return formatNumber(f)
+}
+
+// ampFlexiParameter finds the parameter of an amp in the gear catalog for a
+// knob Amplitube 4 stores as an AmpFlexi* parameter.  Amplitube 5 names it
+// after the knob with a suffix for the amp, such as Gain_THDBiValve.
+func ampFlexiParameter(model string, knob string) (string, bool) {
+	for _, parameter := range GearParameters[model] {
+		if strings.HasPrefix(parameter.Name, knob+"_") {
+			return parameter.Name, true
+		}
+	}
+	return "", false
+}
+
+func isAmpFlexiKnob(name string) bool {
+	for _, knob := range AmpFlexiKnobs {
+		if knob == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isCabLevelAttr(name string) bool {
+	for _, level := range CabLevelAttrs {
+		if level == name {
+			return true
+		}
+	}
+	return false
+}
@@ -65,7 +65,7 @@ func copy(context ExecutionContext) error {
 
 	for _, match := range matches {
 		if isValidPresetFolderName(target) {
-			os.MkdirAll(target, 0775)
+			context.mkdirAll(target, 0775)
 			if recursive {
 				files[match] = filepath.Join(target, match[len(filepath.Dir(source)):])
 			} else {
@@ -80,9 +80,9 @@ func copy(context ExecutionContext) error {
 		}
 	}
 
-	removeExistingDBRecord, err := context.Database.Prepare("delete from pXcPresets where OriginalFileName = ?")
+	removeExistingDBRecord, err := context.prepare("delete from pXcPresets where OriginalFileName = ?")
 
-	statement, err := context.Database.Prepare("insert into pXcPresets (UserId, Product, OriginalFileName, FileFolder, Favorite, Date, Name, Description, Downloads, Keywords, Song, ChainA, ChainB, Band, Artist, ATInstrumentsType, ATPickupType, ATPickupPositions, ATSoundCharacter, ATGenre, SongStructureElement, Rating, MadeWith, ChainType, tstamp, ATInstrument) select UserId, Product, ?, ?, Favorite, Date, ?, Description, Downloads, Keywords, Song, ChainA, ChainB, Band, Artist, ATInstrumentsType, ATPickupType, ATPickupPositions, ATSoundCharacter, ATGenre, SongStructureElement, Rating, MadeWith, ChainType, tstamp, ATInstrument from pXcPresets where OriginalFileName = ?")
+	statement, err := context.prepare("insert into pXcPresets (UserId, Product, OriginalFileName, FileFolder, Favorite, Date, Name, Description, Downloads, Keywords, Song, ChainA, ChainB, Band, Artist, ATInstrumentsType, ATPickupType, ATPickupPositions, ATSoundCharacter, ATGenre, SongStructureElement, Rating, MadeWith, ChainType, tstamp, ATInstrument) select UserId, Product, ?, ?, Favorite, Date, ?, Description, Downloads, Keywords, Song, ChainA, ChainB, Band, Artist, ATInstrumentsType, ATPickupType, ATPickupPositions, ATSoundCharacter, ATGenre, SongStructureElement, Rating, MadeWith, ChainType, tstamp, ATInstrument from pXcPresets where OriginalFileName = ?")
 
 	if err != nil {
 		return errors.New("Failed preparing statement: " + err.Error())
@@ -96,31 +96,31 @@ func copy(context ExecutionContext) error {
 
 		overwritingExisting := isFile(target)
 
-		err = os.MkdirAll(filepath.Dir(target), 0775)
+		err = context.mkdirAll(filepath.Dir(target), 0775)
 
 		if err != nil {
-			rollbackCopy(files)
+			rollbackCopy(context, files)
 			return errors.New("Failed to created directories :" + err.Error())
 		}
 
 		data, err := ioutil.ReadFile(source)
 
 		if err != nil {
-			rollbackCopy(files)
+			rollbackCopy(context, files)
 			return errors.New("Could not read source file: " + err.Error())
 		}
 
-		err = ioutil.WriteFile(target, data, 0644)
+		err = context.writeFile(target, data, 0644)
 
 		if err != nil {
-			rollbackCopy(files)
+			rollbackCopy(context, files)
 			return errors.New("Could not write file: " + err.Error())
 		}
 
-		err = writeNewGuidToFile(target)
+		err = context.newGuid(target)
 
 		if err != nil {
-			rollbackCopy(files)
+			rollbackCopy(context, files)
 			return errors.New("Failed to generate new GUID for copied file: " + err.Error())
 		}
 
@@ -132,7 +132,7 @@ func copy(context ExecutionContext) error {
 
 		if err != nil {
 			statement.Close()
-			rollbackCopy(files)
+			rollbackCopy(context, files)
 			return errors.New("Copy failed.  Failed to update database: " + err.Error())
 		}
 
@@ -143,11 +143,11 @@ func copy(context ExecutionContext) error {
 	return nil
 }
 
-func rollbackCopy(files map[string]string) {
+func rollbackCopy(context ExecutionContext, files map[string]string) {
 	for _, target := range files {
 		_, err := os.Stat(target)
 		if err == nil {
-			os.Remove(target)
+			context.remove(target)
 		}
 	}
 }
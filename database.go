@@ -17,10 +17,25 @@ Copyright (C) 2021 fcbrooks
 package main
 
 import (
+	"context"
 	"database/sql"
+	"path/filepath"
 	"strings"
+
+	"github.com/mattn/go-sqlite3"
 )
 
+type Executor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+type Queryer interface {
+	Executor
+	Prepare(query string) (*sql.Stmt, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
 func openDatabase(dbFile string) (*sql.DB, error) {
 	return sql.Open("sqlite3", strings.ReplaceAll(dbFile, "\\", "/"))
 }
@@ -36,15 +51,121 @@ func tx(runner Runner, context ExecutionContext) error {
 		return err
 	}
 
-	rtrn := runner(context)
+	context.Tx = tx
+
+	rtrn := context.Journal.track(tx)
+
+	if rtrn == nil {
+		rtrn = runner(context)
+	}
 
-	if rtrn != nil {
+	if rtrn == nil {
+		rtrn = context.Journal.collect(tx)
+	}
+
+	if rtrn != nil || context.DryRun {
 		tx.Rollback()
 	} else {
-		tx.Commit()
+		rtrn = tx.Commit()
 	}
 
 	_ = database.Close()
 
 	return rtrn
 }
+
+func insertPresetRecord(database Executor, file string, preset *Element) error {
+
+	description, keywords, song := "", "", ""
+
+	if metaInfo := preset.child("MetaInfo"); metaInfo != nil {
+		description, keywords, song = metaInfo.attr("Description"), metaInfo.attr("KeyWords"), metaInfo.attr("Song")
+	}
+
+	_, err := database.Exec("insert into pXcPresets (UserId, Product, OriginalFileName, FileFolder, Name, Description, Keywords, Song) values ('', '', ?, ?, ?, ?, ?, ?)", file, filepath.Dir(file), makePresetPath(filepath.Base(file)), description, keywords, song)
+
+	return err
+}
+
+// updatePresetRecord sets the details a preset holds in its MetaInfo on its
+// existing record.
+func updatePresetRecord(database Executor, file string, preset *Element) error {
+
+	metaInfo := preset.child("MetaInfo")
+
+	if metaInfo == nil {
+		return nil
+	}
+
+	_, err := database.Exec("update pXcPresets set Description = ?, Keywords = ?, Song = ? where OriginalFileName = ?", metaInfo.attr("Description"), metaInfo.attr("KeyWords"), metaInfo.attr("Song"), file)
+
+	return err
+}
+
+func openProfileDatabase(path string) *sql.DB {
+	source, _ := filepath.Abs(path)
+	profile, err := resolveToProfile(source)
+	if err != nil {
+		return nil
+	}
+	database, err := openDatabase(filepath.Join(profile, "Presets.db"))
+	if err != nil {
+		return nil
+	}
+	if !isV5Database(database) {
+		database.Close()
+		return nil
+	}
+	return database
+}
+
+// backupDatabase copies a database with the SQLite backup API, which gives a
+// consistent copy while other connections have the database open.
+func backupDatabase(source string, target string) error {
+
+	sourceDatabase, err := openDatabase(source)
+
+	if err != nil {
+		return err
+	}
+
+	defer sourceDatabase.Close()
+
+	targetDatabase, err := openDatabase(target)
+
+	if err != nil {
+		return err
+	}
+
+	defer targetDatabase.Close()
+
+	sourceConn, err := sourceDatabase.Conn(context.Background())
+
+	if err != nil {
+		return err
+	}
+
+	defer sourceConn.Close()
+
+	targetConn, err := targetDatabase.Conn(context.Background())
+
+	if err != nil {
+		return err
+	}
+
+	defer targetConn.Close()
+
+	return targetConn.Raw(func(targetDriverConn interface{}) error {
+		return sourceConn.Raw(func(sourceDriverConn interface{}) error {
+			backup, err := targetDriverConn.(*sqlite3.SQLiteConn).Backup("main", sourceDriverConn.(*sqlite3.SQLiteConn), "main")
+			if err != nil {
+				return err
+			}
+			if _, err = backup.Step(-1); err != nil {
+				backup.Close()
+				return err
+			}
+			return backup.Finish()
+		})
+	})
+}
@@ -0,0 +1,301 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type DiffChange struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+type ElementPair struct {
+	Name       string
+	Local      string
+	Occurrence int
+	A          *Element
+	B          *Element
+}
+
+func diff(context ExecutionContext) error {
+
+	if len(context.Args) < 2 {
+		return errors.New("diff requires two presets")
+	}
+
+	jsonOutput := *context.Options["json"].(*bool)
+
+	first, _ := filepath.Abs(context.Args[0])
+	second, _ := filepath.Abs(context.Args[1])
+
+	if !isFile(first) || !isFile(second) {
+		return errors.New("both arguments must be preset files")
+	}
+
+	a, err := readPresetFile(first)
+
+	if err != nil {
+		return err
+	}
+
+	b, err := readPresetFile(second)
+
+	if err != nil {
+		return err
+	}
+
+	changes := diffPresets(a, b)
+
+	if jsonOutput {
+		if changes == nil {
+			changes = []DiffChange{}
+		}
+		data, err := json.MarshalIndent(changes, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	if len(changes) == 0 {
+		fmt.Fprintln(out, "presets are identical")
+	}
+
+	for _, change := range changes {
+		switch change.Type {
+		case "changed":
+			fmt.Fprintln(out, "~ "+change.Path+": "+change.Old+" -> "+change.New)
+		case "added":
+			fmt.Fprintln(out, "+ "+change.Path+": "+change.New)
+		case "removed":
+			fmt.Fprintln(out, "- "+change.Path+": "+change.Old)
+		case "moved":
+			fmt.Fprintln(out, "> "+change.Path+": "+change.Old+" -> "+change.New)
+		}
+	}
+
+	return nil
+}
+
+func diffPresets(a *Element, b *Element) []DiffChange {
+
+	var changes []DiffChange
+
+	schema, err := schemaForFormat(a.attr("Format"))
+
+	if err != nil || a.attr("Format") != b.attr("Format") {
+		diffElements("Preset", a, b, &changes)
+		return changes
+	}
+
+	diffAttrs("Preset", a, b, []string{"GUID"}, &changes)
+
+	for _, pair := range pairChildren(a, b) {
+		path := pair.Name
+		if pair.A == nil || pair.B == nil {
+			diffElements(path, pair.A, pair.B, &changes)
+			continue
+		}
+		block, ok := schema.block(path)
+		if !ok {
+			diffElements(path, pair.A, pair.B, &changes)
+			continue
+		}
+		switch block.Kind {
+		case AmpBlock:
+			diffModel(path, "Model", Amps, pair.A, pair.B, &changes)
+		case CabBlock:
+			diffModel(path, "CabModel", Cabs, pair.A, pair.B, &changes)
+		case FxBlock:
+			diffFxBlock(path, block, pair.A, pair.B, &changes)
+		default:
+			diffElements(path, pair.A, pair.B, &changes)
+		}
+	}
+
+	return changes
+}
+
+func diffModel(path string, attr string, models map[string]string, a *Element, b *Element, changes *[]DiffChange) {
+	if a.attr(attr) != b.attr(attr) {
+		*changes = append(*changes, DiffChange{Path: path, Type: "changed", Old: getValueOrKey(models, a.attr(attr)), New: getValueOrKey(models, b.attr(attr))})
+		diffAttrs(path, a, b, []string{attr}, changes)
+		return
+	}
+	diffElements(path, a, b, changes)
+}
+
+func diffFxBlock(path string, block BlockSchema, a *Element, b *Element, changes *[]DiffChange) {
+
+	var ignore []string
+	for i := 0; i < block.Slots; i++ {
+		ignore = append(ignore, block.slotAttr(i))
+	}
+
+	diffAttrs(path, a, b, ignore, changes)
+
+	aSlots := fxSlots(a, block)
+	bSlots := fxSlots(b, block)
+	matched := make([]bool, len(bSlots))
+	var unmatched []int
+
+	for i, slot := range aSlots {
+		if isEmptyFx(slot.GUID) {
+			continue
+		}
+		if bSlots[i].GUID == slot.GUID {
+			matched[i] = true
+			diffAttrs(path+"."+slotName(i), slot.Slot, bSlots[i].Slot, nil, changes)
+			continue
+		}
+		unmatched = append(unmatched, i)
+	}
+
+	for _, i := range unmatched {
+		slot := aSlots[i]
+		found := false
+		for j, other := range bSlots {
+			if !matched[j] && other.GUID == slot.GUID && aSlots[j].GUID != other.GUID {
+				matched[j] = true
+				found = true
+				*changes = append(*changes, DiffChange{Path: path + "." + slotName(i), Type: "moved", Old: getValueOrKey(FX, slot.GUID), New: slotName(j)})
+				diffAttrs(path+"."+slotName(j), slot.Slot, other.Slot, nil, changes)
+				break
+			}
+		}
+		if !found {
+			*changes = append(*changes, DiffChange{Path: path + "." + slotName(i), Type: "removed", Old: getValueOrKey(FX, slot.GUID)})
+		}
+	}
+
+	for j, slot := range bSlots {
+		if !matched[j] && !isEmptyFx(slot.GUID) {
+			*changes = append(*changes, DiffChange{Path: path + "." + slotName(j), Type: "added", New: getValueOrKey(FX, slot.GUID)})
+		}
+	}
+
+	for _, pair := range pairChildren(a, b) {
+		if strings.Index(pair.Name, "Slot") != 0 {
+			diffElements(path+"."+pair.Name, pair.A, pair.B, changes)
+		}
+	}
+}
+
+func diffElements(path string, a *Element, b *Element, changes *[]DiffChange) {
+	if a == nil {
+		*changes = append(*changes, DiffChange{Path: path, Type: "added", New: b.XMLName.Local})
+		return
+	}
+	if b == nil {
+		*changes = append(*changes, DiffChange{Path: path, Type: "removed", Old: a.XMLName.Local})
+		return
+	}
+	diffAttrs(path, a, b, nil, changes)
+	for _, pair := range pairChildren(a, b) {
+		diffElements(path+"."+pair.Name, pair.A, pair.B, changes)
+	}
+}
+
+func diffAttrs(path string, a *Element, b *Element, ignore []string, changes *[]DiffChange) {
+	skip := map[string]bool{}
+	for _, name := range ignore {
+		skip[name] = true
+	}
+	for _, attr := range a.Attrs {
+		name := attr.Name.Local
+		if skip[name] {
+			continue
+		}
+		if !b.hasAttr(name) {
+			*changes = append(*changes, DiffChange{Path: path + "." + name, Type: "removed", Old: attrDisplayValue(name, attr.Value)})
+		} else if b.attr(name) != attr.Value {
+			*changes = append(*changes, DiffChange{Path: path + "." + name, Type: "changed", Old: attrDisplayValue(name, attr.Value), New: attrDisplayValue(name, b.attr(name))})
+		}
+	}
+	for _, attr := range b.Attrs {
+		name := attr.Name.Local
+		if !skip[name] && !a.hasAttr(name) {
+			*changes = append(*changes, DiffChange{Path: path + "." + name, Type: "added", New: attrDisplayValue(name, attr.Value)})
+		}
+	}
+}
+
+func pairChildren(a *Element, b *Element) []ElementPair {
+	var pairs []ElementPair
+	seen := map[string]int{}
+	for _, c := range a.Children {
+		name := c.XMLName.Local
+		pairs = append(pairs, ElementPair{Name: occurrenceName(name, seen[name]), Local: name, Occurrence: seen[name], A: c, B: nthChild(b, name, seen[name])})
+		seen[name]++
+	}
+	counts := map[string]int{}
+	for _, c := range b.Children {
+		name := c.XMLName.Local
+		if counts[name] >= seen[name] {
+			pairs = append(pairs, ElementPair{Name: occurrenceName(name, counts[name]), Local: name, Occurrence: counts[name], B: c})
+		}
+		counts[name]++
+	}
+	return pairs
+}
+
+func nthChild(e *Element, name string, n int) *Element {
+	for _, c := range e.Children {
+		if c.XMLName.Local == name {
+			if n == 0 {
+				return c
+			}
+			n--
+		}
+	}
+	return nil
+}
+
+func occurrenceName(name string, n int) string {
+	if n == 0 {
+		return name
+	}
+	return name + "[" + strconv.Itoa(n) + "]"
+}
+
+func attrDisplayValue(name string, value string) string {
+	switch {
+	case name == "Model":
+		return getValueOrKey(Amps, value)
+	case name == "CabModel":
+		return getValueOrKey(Cabs, value)
+	case strings.Index(name, "SpeakerModel") == 0:
+		return getValueOrKey(Speakers, value)
+	case name == "Mic0Model" || name == "Mic1Model":
+		return getValueOrKey(Mics, value)
+	case strings.Index(name, "Stomp") == 0:
+		if _, err := strconv.Atoi(name[5:]); err == nil {
+			return getValueOrKey(FX, value)
+		}
+	}
+	return value
+}
@@ -0,0 +1,182 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+type DoctorIssue struct {
+	Kind   string
+	File   string
+	Detail string
+}
+
+func doctor(context ExecutionContext) error {
+
+	if len(context.Args) == 0 {
+		return errors.New("arg must be the root of an Amplitube profile")
+	}
+
+	profile, _ := filepath.Abs(context.Args[0])
+
+	if !isProfileFolder(profile) {
+		return errors.New("arg must be the root of an Amplitube profile")
+	}
+
+	if context.Database == nil {
+		return errors.New("preset database not found")
+	}
+
+	fix := *context.Options["fix"].(*bool)
+
+	files := map[string]bool{}
+
+	filepath.Walk(filepath.Join(profile, PresetsFolder), func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && isValidPresetName(path) {
+			files[path] = true
+		}
+		return nil
+	})
+
+	var issues []DoctorIssue
+
+	rows, err := context.Query("select OriginalFileName, FileFolder, Name from pXcPresets")
+
+	if err != nil {
+		return err
+	}
+
+	records := map[string]bool{}
+
+	for rows.Next() {
+		var file string
+		var nullableFolder, nullableName interface{}
+		if err = rows.Scan(&file, &nullableFolder, &nullableName); err != nil {
+			rows.Close()
+			return err
+		}
+		folder, name := nullString(nullableFolder), nullString(nullableName)
+		records[file] = true
+		if !files[file] {
+			issues = append(issues, DoctorIssue{Kind: "orphaned record", File: file})
+			continue
+		}
+		if folder != filepath.Dir(file) {
+			issues = append(issues, DoctorIssue{Kind: "invalid FileFolder", File: file, Detail: folder})
+		}
+		if name != makePresetPath(filepath.Base(file)) {
+			issues = append(issues, DoctorIssue{Kind: "invalid Name", File: file, Detail: name})
+		}
+	}
+
+	rows.Close()
+
+	guids := map[string][]string{}
+
+	for _, file := range sortedFiles(files) {
+		preset, err := readPresetFile(file)
+		if err != nil {
+			issues = append(issues, DoctorIssue{Kind: "invalid preset", File: file, Detail: err.Error()})
+			continue
+		}
+		if !records[file] {
+			issues = append(issues, DoctorIssue{Kind: "missing record", File: file})
+		}
+		guids[preset.attr("GUID")] = append(guids[preset.attr("GUID")], file)
+	}
+
+	for guid, matches := range guids {
+		for _, file := range matches[1:] {
+			issues = append(issues, DoctorIssue{Kind: "duplicate GUID", File: file, Detail: guid})
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].File < issues[j].File
+	})
+
+	for _, issue := range issues {
+		line := issue.Kind + ": " + issue.File
+		if issue.Detail != "" {
+			line = line + " (" + issue.Detail + ")"
+		}
+		fmt.Fprintln(out, line)
+	}
+
+	if len(issues) == 0 {
+		fmt.Fprintln(out, "no issues found")
+		return nil
+	}
+
+	if !fix {
+		fmt.Fprintln(out, strconv.Itoa(len(issues))+" issue(s) found; run with -fix to repair")
+		return nil
+	}
+
+	fixed := 0
+
+	for _, issue := range issues {
+		switch issue.Kind {
+		case "orphaned record":
+			_, err = context.Exec("delete from pXcPresets where OriginalFileName = ?", issue.File)
+		case "invalid FileFolder", "invalid Name":
+			_, err = context.Exec("update pXcPresets set FileFolder = ?, Name = ? where OriginalFileName = ?", filepath.Dir(issue.File), makePresetPath(filepath.Base(issue.File)), issue.File)
+		case "missing record":
+			var preset *Element
+			if preset, err = readPresetFile(issue.File); err == nil {
+				err = insertPresetRecord(context, issue.File, preset)
+			}
+		case "duplicate GUID":
+			err = context.newGuid(issue.File)
+		default:
+			continue
+		}
+		if err != nil {
+			return errors.New("Failed to repair " + issue.File + ": " + err.Error())
+		}
+		fixed++
+	}
+
+	fmt.Fprintln(out, strconv.Itoa(fixed)+" of "+strconv.Itoa(len(issues))+" issue(s) fixed")
+
+	return nil
+}
+
+func sortedFiles(files map[string]bool) []string {
+	var sorted []string
+	for file := range files {
+		sorted = append(sorted, file)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func nullString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,284 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type PresetDocument struct {
+	Name   string      `json:"name"`
+	Preset *DocElement `json:"preset"`
+}
+
+type DocElement struct {
+	Name       string        `json:"name"`
+	Model      string        `json:"model,omitempty"`
+	Attributes DocAttrs      `json:"attributes,omitempty"`
+	Slots      []DocSlot     `json:"slots,omitempty"`
+	Children   []*DocElement `json:"children,omitempty"`
+}
+
+type DocSlot struct {
+	Slot       int           `json:"slot"`
+	Model      string        `json:"model,omitempty"`
+	GUID       string        `json:"guid,omitempty"`
+	Attributes DocAttrs      `json:"attributes,omitempty"`
+	Children   []*DocElement `json:"children,omitempty"`
+}
+
+// DocAttrs keeps attributes in preset order when written to or read from a
+// document, so a round trip reproduces the original preset.
+type DocAttrs []xml.Attr
+
+func (a DocAttrs) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("{")
+	for i, attr := range a {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		key, _ := json.Marshal(attr.Name.Local)
+		value, _ := json.Marshal(attr.Value)
+		buf.Write(key)
+		buf.WriteString(":")
+		buf.Write(value)
+	}
+	buf.WriteString("}")
+	return buf.Bytes(), nil
+}
+
+func (a *DocAttrs) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return errors.New("attributes must be an object")
+	}
+	*a = DocAttrs{}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		value, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if _, ok := value.(json.Delim); ok {
+			return errors.New("attribute " + fmt.Sprint(key) + " must be a single value")
+		}
+		if value == nil {
+			value = ""
+		}
+		*a = append(*a, xml.Attr{Name: xml.Name{Local: fmt.Sprint(key)}, Value: fmt.Sprint(value)})
+	}
+	return nil
+}
+
+func presetToDocument(name string, preset *Element) *PresetDocument {
+	schema, _ := schemaForFormat(preset.attr("Format"))
+	return &PresetDocument{Name: name, Preset: elementToDoc(preset, schema, true)}
+}
+
+func elementToDoc(element *Element, schema PresetSchema, root bool) *DocElement {
+
+	doc := &DocElement{Name: element.XMLName.Local, Attributes: DocAttrs(element.Attrs)}
+
+	block, isBlock := schema.block(element.XMLName.Local)
+	isBlock = isBlock && !root
+
+	if isBlock && block.Kind == AmpBlock {
+		doc.Model = Amps[element.attr("Model")]
+	}
+
+	if isBlock && block.Kind == CabBlock {
+		doc.Model = Cabs[element.attr("CabModel")]
+	}
+
+	if isBlock && block.Kind == FxBlock {
+		doc.Attributes = nil
+		for _, attr := range element.Attrs {
+			if !block.isSlotAttr(attr.Name.Local) {
+				doc.Attributes = append(doc.Attributes, attr)
+			}
+		}
+		for i, slot := range fxSlots(element, block) {
+			if isEmptyFx(slot.GUID) {
+				continue
+			}
+			docSlot := DocSlot{Slot: i, Model: FX[slot.GUID], GUID: slot.GUID, Attributes: DocAttrs(slot.Slot.Attrs)}
+			for _, c := range slot.Slot.Children {
+				docSlot.Children = append(docSlot.Children, elementToDoc(c, schema, false))
+			}
+			doc.Slots = append(doc.Slots, docSlot)
+		}
+		for _, c := range element.Children {
+			if !isSlotName(c.XMLName.Local) {
+				doc.Children = append(doc.Children, elementToDoc(c, PresetSchema{}, false))
+			}
+		}
+		return doc
+	}
+
+	childSchema := PresetSchema{}
+	if root {
+		childSchema = schema
+	}
+
+	for _, c := range element.Children {
+		doc.Children = append(doc.Children, elementToDoc(c, childSchema, false))
+	}
+
+	return doc
+}
+
+func documentToPreset(document *PresetDocument) (*Element, error) {
+	if document.Preset == nil || document.Preset.Name != "Preset" {
+		return nil, errors.New("document does not contain a preset")
+	}
+	schema, _ := schemaForFormat(document.Preset.Attributes.value("Format"))
+	return docToElement(document.Preset, schema, true)
+}
+
+func docToElement(doc *DocElement, schema PresetSchema, root bool) (*Element, error) {
+
+	if doc.Name == "" {
+		return nil, errors.New("document element missing name")
+	}
+
+	element := &Element{XMLName: xml.Name{Local: doc.Name}}
+
+	if len(doc.Attributes) > 0 {
+		element.Attrs = append([]xml.Attr{}, doc.Attributes...)
+	}
+
+	block, isBlock := schema.block(doc.Name)
+	isBlock = isBlock && !root
+
+	if isBlock && doc.Model != "" && block.Kind == AmpBlock {
+		if err := applyDocModel(element, "Model", Amps, doc.Model); err != nil {
+			return nil, err
+		}
+	}
+
+	if isBlock && doc.Model != "" && block.Kind == CabBlock {
+		if err := applyDocModel(element, "CabModel", Cabs, doc.Model); err != nil {
+			return nil, err
+		}
+	}
+
+	childSchema := PresetSchema{}
+	if root {
+		childSchema = schema
+	}
+
+	if isBlock && block.Kind == FxBlock {
+		childSchema = PresetSchema{}
+	}
+
+	for _, c := range doc.Children {
+		child, err := docToElement(c, childSchema, false)
+		if err != nil {
+			return nil, err
+		}
+		element.Children = append(element.Children, child)
+	}
+
+	if isBlock && block.Kind == FxBlock {
+		slots := make([]FxSlot, block.Slots)
+		for _, docSlot := range doc.Slots {
+			if docSlot.Slot < 0 || docSlot.Slot >= block.Slots {
+				return nil, errors.New("invalid slot " + slotName(docSlot.Slot) + " for " + doc.Name)
+			}
+			guid := docSlot.GUID
+			if guid == "" || (docSlot.Model != "" && docSlot.Model != FX[guid]) {
+				found, ok := lookupGUID(FX, docSlot.Model)
+				if !ok {
+					return nil, errors.New("unknown fx model " + docSlot.Model)
+				}
+				guid = found
+			}
+			slot := &Element{XMLName: xml.Name{Local: slotName(docSlot.Slot)}}
+			if len(docSlot.Attributes) > 0 {
+				slot.Attrs = append([]xml.Attr{}, docSlot.Attributes...)
+			}
+			for _, c := range docSlot.Children {
+				child, err := docToElement(c, PresetSchema{}, false)
+				if err != nil {
+					return nil, err
+				}
+				slot.Children = append(slot.Children, child)
+			}
+			slots[docSlot.Slot] = FxSlot{GUID: guid, Slot: slot}
+		}
+		setFxSlots(element, block, slots)
+	} else if len(doc.Slots) > 0 {
+		return nil, errors.New(doc.Name + " does not have effect slots")
+	}
+
+	return element, nil
+}
+
+func applyDocModel(element *Element, attr string, models map[string]string, model string) error {
+	if model == models[element.attr(attr)] {
+		return nil
+	}
+	guid, ok := lookupGUID(models, model)
+	if !ok {
+		return errors.New("unknown model " + model)
+	}
+	element.putAttr(attr, guid)
+	return nil
+}
+
+func (a DocAttrs) value(name string) string {
+	for _, attr := range a {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func lookupGUID(models map[string]string, name string) (string, bool) {
+	if _, ok := models[name]; ok {
+		return name, true
+	}
+	// several models may share a name, so the lowest GUID is taken to give
+	// the same result on every run
+	var found []string
+	for guid, model := range models {
+		if strings.EqualFold(model, name) && !isEmptyFx(guid) {
+			found = append(found, guid)
+		}
+	}
+	if len(found) == 0 {
+		return "", false
+	}
+	sort.Strings(found)
+	return found[0], true
+}
+
+func isSlotName(name string) bool {
+	return strings.Index(name, "Slot") == 0 && len(name) > 4 && strings.Trim(name[4:], "0123456789") == ""
+}
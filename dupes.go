@@ -0,0 +1,323 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const DefaultSimilarity = 0.9
+
+type PresetFingerprint struct {
+	File   string
+	Hash   string
+	Gear   string
+	Values map[string]string
+}
+
+type DuplicateGroup struct {
+	Identical    bool
+	Files        []string
+	Similarities []float64
+}
+
+func dupes(context ExecutionContext) error {
+
+	if len(context.Args) < 1 {
+		return errors.New("dupes requires a preset folder")
+	}
+
+	threshold := *context.Options["threshold"].(*float64)
+	removeDupes := *context.Options["remove"].(*bool)
+	moveTo := *context.Options["move"].(*string)
+
+	if threshold <= 0 || threshold > 1 {
+		return errors.New("similarity threshold must be greater than 0 and at most 1")
+	}
+
+	if removeDupes && moveTo != "" {
+		return errors.New("cannot both remove and move duplicates")
+	}
+
+	root, _ := filepath.Abs(context.Args[0])
+
+	matches, err := resolveToMatches(root, true, true)
+
+	if err != nil {
+		return err
+	}
+
+	var fingerprints []PresetFingerprint
+
+	for _, match := range sortMatches(matches) {
+
+		if !isValidPresetName(match) {
+			continue
+		}
+
+		preset, err := readPresetFile(match)
+
+		if err != nil {
+			return err
+		}
+
+		fingerprints = append(fingerprints, presetFingerprint(match, preset))
+	}
+
+	groups := duplicateGroups(fingerprints, threshold)
+
+	if len(groups) == 0 {
+		fmt.Fprintln(out, "no duplicates found")
+		return nil
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(out, "")
+		}
+		if group.Identical {
+			fmt.Fprintln(out, "identical:")
+		} else {
+			fmt.Fprintln(out, "similar:")
+		}
+		for j, file := range group.Files {
+			name, _ := filepath.Rel(root, file)
+			line := "    " + name
+			if !group.Identical && j > 0 {
+				line += "  " + strconv.Itoa(int(group.Similarities[j]*100)) + "%"
+			}
+			fmt.Fprintln(out, line)
+		}
+	}
+
+	if removeDupes {
+		return removeDuplicates(context, groups)
+	}
+
+	if moveTo != "" {
+		return moveDuplicates(context, groups, moveTo)
+	}
+
+	return nil
+}
+
+// presetFingerprint flattens the attributes of a preset, leaving out those
+// that differ between copies of the same sound.
+func presetFingerprint(file string, preset *Element) PresetFingerprint {
+
+	values := map[string]string{}
+
+	for _, attr := range preset.Attrs {
+		if attr.Name.Local != "GUID" && attr.Name.Local != "ProgramChange" {
+			values["Preset."+attr.Name.Local] = attr.Value
+		}
+	}
+
+	seen := map[string]int{}
+
+	for _, child := range preset.Children {
+		name := child.XMLName.Local
+		if name != "MetaInfo" {
+			flattenElement("Preset."+occurrenceName(name, seen[name]), child, values)
+		}
+		seen[name]++
+	}
+
+	var keys []string
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var gear []string
+
+	for _, ref := range presetGear(preset) {
+		gear = append(gear, ref.Block+"."+ref.Type+"="+ref.GUID)
+	}
+
+	hash := sha256.New()
+
+	for _, key := range keys {
+		hash.Write([]byte(key + "=" + values[key] + "\n"))
+	}
+
+	return PresetFingerprint{File: file, Hash: hex.EncodeToString(hash.Sum(nil)), Gear: strings.Join(gear, ","), Values: values}
+}
+
+func flattenElement(path string, element *Element, values map[string]string) {
+	values[path] = ""
+	for _, attr := range element.Attrs {
+		values[path+"."+attr.Name.Local] = attr.Value
+	}
+	seen := map[string]int{}
+	for _, child := range element.Children {
+		name := child.XMLName.Local
+		flattenElement(path+"."+occurrenceName(name, seen[name]), child, values)
+		seen[name]++
+	}
+}
+
+// similarity is the share of attributes, from either preset, that have the
+// same value in both.
+func (f PresetFingerprint) similarity(other PresetFingerprint) float64 {
+	same := 0
+	for key, value := range f.Values {
+		if otherValue, ok := other.Values[key]; ok && otherValue == value {
+			same++
+		}
+	}
+	total := len(f.Values) + len(other.Values) - same
+	if total == 0 {
+		return 1
+	}
+	return float64(same) / float64(total)
+}
+
+// duplicateGroups groups presets with the same fingerprint, then clusters
+// the distinct presets using the same gear with those at least as similar as
+// the threshold.
+func duplicateGroups(fingerprints []PresetFingerprint, threshold float64) []DuplicateGroup {
+
+	var groups []DuplicateGroup
+	var distinct []PresetFingerprint
+	byHash := map[string]int{}
+
+	for _, fingerprint := range fingerprints {
+		if index, ok := byHash[fingerprint.Hash]; ok {
+			groups[index].Files = append(groups[index].Files, fingerprint.File)
+			groups[index].Similarities = append(groups[index].Similarities, 1)
+			continue
+		}
+		byHash[fingerprint.Hash] = len(groups)
+		groups = append(groups, DuplicateGroup{Identical: true, Files: []string{fingerprint.File}, Similarities: []float64{1}})
+		distinct = append(distinct, fingerprint)
+	}
+
+	var duplicates []DuplicateGroup
+
+	for _, group := range groups {
+		if len(group.Files) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+
+	if threshold >= 1 {
+		return duplicates
+	}
+
+	clustered := make([]bool, len(distinct))
+
+	for i := range distinct {
+		if clustered[i] {
+			continue
+		}
+		group := DuplicateGroup{Files: []string{distinct[i].File}, Similarities: []float64{1}}
+		members := []int{i}
+		for k := 0; k < len(members); k++ {
+			for j := i + 1; j < len(distinct); j++ {
+				if !clustered[j] && distinct[j].Gear == distinct[i].Gear && distinct[members[k]].similarity(distinct[j]) >= threshold {
+					clustered[j] = true
+					members = append(members, j)
+					group.Files = append(group.Files, distinct[j].File)
+					group.Similarities = append(group.Similarities, distinct[i].similarity(distinct[j]))
+				}
+			}
+		}
+		if len(group.Files) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+
+	return duplicates
+}
+
+// duplicateFiles returns every file of the groups but the first, without
+// listing a file twice when it is in both an identical and a similar group.
+func duplicateFiles(groups []DuplicateGroup) []string {
+	var files []string
+	added := map[string]bool{}
+	kept := map[string]bool{}
+	for _, group := range groups {
+		if added[group.Files[0]] {
+			continue
+		}
+		kept[group.Files[0]] = true
+		for _, file := range group.Files[1:] {
+			if !added[file] && !kept[file] {
+				added[file] = true
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
+
+func removeDuplicates(context ExecutionContext, groups []DuplicateGroup) error {
+	recursive := false
+	removeContext := context
+	removeContext.Options = map[string]interface{}{"recursive": &recursive}
+	for _, file := range duplicateFiles(groups) {
+		removeContext.Args = []string{file}
+		if err := remove(removeContext); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func moveDuplicates(context ExecutionContext, groups []DuplicateGroup, folder string) error {
+
+	target, _ := filepath.Abs(folder)
+
+	if !isInPresetsFolder(target) || !isValidPresetFolderName(target) {
+		return errors.New("move target must be a folder in the presets folder")
+	}
+
+	files := duplicateFiles(groups)
+	names := map[string]string{}
+
+	for _, file := range files {
+		name := strings.ToLower(filepath.Base(file))
+		if other, ok := names[name]; ok {
+			return errors.New("cannot move both " + other + " and " + file + " to " + folder)
+		}
+		if isFile(filepath.Join(target, filepath.Base(file))) {
+			return errors.New(filepath.Join(folder, filepath.Base(file)) + " already exists")
+		}
+		names[name] = file
+	}
+
+	moveContext := context
+
+	for _, file := range files {
+		moveContext.Args = []string{file, target}
+		if err := move(moveContext); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
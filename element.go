@@ -0,0 +1,189 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"path"
+	"strings"
+)
+
+// Element is an ordered, schema-less view of a preset XML element.  Reading
+// and writing a preset through Element preserves every attribute and child
+// in its original order.
+type Element struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Children []*Element `xml:",any"`
+}
+
+func readPresetFile(file string) (*Element, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return parsePreset(data)
+}
+
+func parsePreset(data []byte) (*Element, error) {
+	var preset Element
+	if err := xml.Unmarshal(data, &preset); err != nil {
+		return nil, err
+	}
+	if preset.XMLName.Local != "Preset" {
+		return nil, errors.New("not a preset file")
+	}
+	return &preset, nil
+}
+
+func writePresetFile(file string, preset *Element) error {
+	data, err := marshalPreset(preset)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(file, data, 0664)
+}
+
+func marshalPreset(preset *Element) ([]byte, error) {
+	data, err := xml.MarshalIndent(preset, "", "    ")
+	if err != nil {
+		return nil, err
+	}
+	return selfClose(append([]byte(XMLPrefix), append(data, '\n')...)), nil
+}
+
+func (e *Element) child(name string) *Element {
+	for _, c := range e.Children {
+		if c.XMLName.Local == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (e *Element) find(path []string) (*Element, error) {
+	current := e
+	for _, name := range path {
+		next := current.child(name)
+		if next == nil {
+			return nil, errors.New("invalid or unsupported path " + strings.Join(path, "."))
+		}
+		current = next
+	}
+	return current, nil
+}
+
+func (e *Element) findAll(pattern []string) []*Element {
+	current := []*Element{e}
+	for _, name := range pattern {
+		var next []*Element
+		for _, element := range current {
+			for _, c := range element.Children {
+				if ok, _ := path.Match(name, c.XMLName.Local); ok {
+					next = append(next, c)
+				}
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+func (e *Element) matchAttrs(pattern string) []*xml.Attr {
+	var attrs []*xml.Attr
+	for i := range e.Attrs {
+		if ok, _ := path.Match(pattern, e.Attrs[i].Name.Local); ok {
+			attrs = append(attrs, &e.Attrs[i])
+		}
+	}
+	return attrs
+}
+
+func (e *Element) replaceChild(name string, child *Element) {
+	for i, c := range e.Children {
+		if c.XMLName.Local == name {
+			e.Children[i] = child
+			return
+		}
+	}
+	e.Children = append(e.Children, child)
+}
+
+func (e *Element) removeChild(name string) {
+	for i, c := range e.Children {
+		if c.XMLName.Local == name {
+			e.Children = append(e.Children[:i], e.Children[i+1:]...)
+			return
+		}
+	}
+}
+
+func (e *Element) attr(name string) string {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (e *Element) hasAttr(name string) bool {
+	for _, a := range e.Attrs {
+		if a.Name.Local == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Element) setAttr(name string, value string) error {
+	for i, a := range e.Attrs {
+		if a.Name.Local == name {
+			e.Attrs[i].Value = value
+			return nil
+		}
+	}
+	return errors.New("attribute not found: " + name)
+}
+
+func (e *Element) putAttr(name string, value string) {
+	if e.setAttr(name, value) != nil {
+		e.Attrs = append(e.Attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+	}
+}
+
+func (e *Element) removeAttr(name string) {
+	for i, a := range e.Attrs {
+		if a.Name.Local == name {
+			e.Attrs = append(e.Attrs[:i], e.Attrs[i+1:]...)
+			return
+		}
+	}
+}
+
+func (e *Element) clone() *Element {
+	c := &Element{XMLName: e.XMLName}
+	if e.Attrs != nil {
+		c.Attrs = append([]xml.Attr{}, e.Attrs...)
+	}
+	for _, child := range e.Children {
+		c.Children = append(c.Children, child.clone())
+	}
+	return c
+}
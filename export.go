@@ -0,0 +1,180 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+func export(context ExecutionContext) error {
+
+	if len(context.Args) < 1 {
+		return errors.New("export requires a preset")
+	}
+
+	format := *context.Options["format"].(*string)
+	output := *context.Options["output"].(*string)
+
+	source, _ := filepath.Abs(context.Args[0])
+
+	if !isFile(source) || !isValidPresetName(source) {
+		return errors.New("first argument must be a preset file")
+	}
+
+	preset, err := readPresetFile(source)
+
+	if err != nil {
+		return err
+	}
+
+	name := filepath.Base(source)
+	document := presetToDocument(name[:len(name)-len(filepath.Ext(name))], preset)
+
+	data, err := json.MarshalIndent(document, "", "    ")
+
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+	case "yaml":
+		data, err = jsonToYAML(data)
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("unsupported export format " + format)
+	}
+
+	if output == "" {
+		fmt.Fprintln(out, strings.TrimRight(string(data), "\n"))
+		return nil
+	}
+
+	return context.writeFile(output, append([]byte(strings.TrimRight(string(data), "\n")), '\n'), 0664)
+}
+
+func importDocument(context ExecutionContext) error {
+
+	if len(context.Args) < 2 {
+		return errors.New("import-doc requires a document and a target preset or folder")
+	}
+
+	data, err := ioutil.ReadFile(context.Args[0])
+
+	if err != nil {
+		return err
+	}
+
+	ext := strings.ToLower(filepath.Ext(context.Args[0]))
+
+	if ext == ".yaml" || ext == ".yml" || (ext != ".json" && !strings.HasPrefix(strings.TrimSpace(string(data)), "{")) {
+		data, err = yamlToJSON(data)
+		if err != nil {
+			return errors.New("invalid document: " + err.Error())
+		}
+	}
+
+	var document PresetDocument
+
+	if err = json.Unmarshal(data, &document); err != nil {
+		return errors.New("invalid document: " + err.Error())
+	}
+
+	preset, err := documentToPreset(&document)
+
+	if err != nil {
+		return err
+	}
+
+	target, _ := filepath.Abs(context.Args[1])
+
+	if !isValidPresetName(target) {
+		if document.Name == "" {
+			return errors.New("document has no preset name")
+		}
+		extension := PresetExtension
+		if preset.attr("Format") == "at4p" {
+			extension = PresetExtension4
+		}
+		target = filepath.Join(target, document.Name+extension)
+	}
+
+	if !isDir(filepath.Dir(target)) {
+		return errors.New("target folder not found")
+	}
+
+	if !isInPresetsFolder(target) {
+		return errors.New("presets not found on path")
+	}
+
+	var original []byte
+
+	if isFile(target) {
+		if !*context.Options["force"].(*bool) {
+			return errors.New(target + " already exists; use -force to replace it")
+		}
+		if original, err = ioutil.ReadFile(target); err != nil {
+			return err
+		}
+	}
+
+	newId, _ := uuid.NewRandom()
+	preset.putAttr("GUID", newId.String())
+
+	if err = context.writePreset(target, preset); err != nil {
+		return err
+	}
+
+	if context.Database == nil {
+		fmt.Fprintln(out, target+" imported without database record")
+		return nil
+	}
+
+	// a replaced preset keeps its record so its rating, band and other
+	// details not held in the preset are not lost
+	recorded := 0
+	context.QueryRow("select count(*) from pXcPresets where OriginalFileName = ?", target).Scan(&recorded)
+
+	if recorded > 0 {
+		err = updatePresetRecord(context, target, preset)
+	} else {
+		err = insertPresetRecord(context, target, preset)
+	}
+
+	if err != nil {
+		rollbackImportDocument(context, target, original)
+		return errors.New("Import failed.  Failed to update database: " + err.Error())
+	}
+
+	return nil
+}
+
+func rollbackImportDocument(context ExecutionContext, target string, original []byte) {
+	if original == nil {
+		context.remove(target)
+	} else {
+		context.writeFile(target, original, 0664)
+	}
+}
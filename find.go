@@ -0,0 +1,255 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+var FindOperators = []string{"!=", ">=", "<=", "=", ">", "<", "~"}
+
+var GearTypes = []string{"amp", "cab", "speaker", "mic", "fx"}
+
+type FindFilter struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+func find(context ExecutionContext) error {
+
+	if len(context.Args) < 1 {
+		return errors.New("find requires a path")
+	}
+
+	table := *context.Options["table"].(*bool)
+
+	var filters []FindFilter
+
+	for _, arg := range context.Args[1:] {
+		filter, err := parseFindFilter(arg)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+	}
+
+	matches, err := resolveToMatches(context.Args[0], true, true)
+
+	if err != nil {
+		return err
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	if table {
+		fmt.Fprintln(writer, "NAME\tCHAIN\tAMPS\tCABS\tFOLDER")
+	}
+
+	for _, match := range sortMatches(matches) {
+
+		if !isValidPresetName(match) {
+			continue
+		}
+
+		preset, err := readPresetFile(match)
+
+		if err != nil {
+			continue
+		}
+
+		found := true
+
+		for _, filter := range filters {
+			ok, err := matchFindFilter(context, match, preset, filter)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				found = false
+				break
+			}
+		}
+
+		if !found {
+			continue
+		}
+
+		if table {
+			var amps, cabs []string
+			for _, gear := range presetGear(preset) {
+				if gear.Type == "amp" {
+					amps = append(amps, gear.Name)
+				} else if gear.Type == "cab" {
+					cabs = append(cabs, gear.Name)
+				}
+			}
+			chain := ""
+			if element := preset.child("Chain"); element != nil {
+				chain = element.attr("Preset")
+			}
+			name := filepath.Base(match)
+			name = name[:len(name)-len(filepath.Ext(name))]
+			fmt.Fprintln(writer, name+"\t"+chain+"\t"+strings.Join(amps, ", ")+"\t"+strings.Join(cabs, ", ")+"\t"+presetFolder(match))
+		} else {
+			fmt.Fprintln(writer, match)
+		}
+
+	}
+
+	return writer.Flush()
+}
+
+func parseFindFilter(arg string) (FindFilter, error) {
+	index := strings.IndexAny(arg, "!=<>~")
+	if index < 1 {
+		return FindFilter{}, errors.New("invalid filter " + arg)
+	}
+	for _, op := range FindOperators {
+		if strings.HasPrefix(arg[index:], op) {
+			return FindFilter{Key: arg[:index], Op: op, Value: arg[index+len(op):]}, nil
+		}
+	}
+	return FindFilter{}, errors.New("invalid filter " + arg)
+}
+
+func matchFindFilter(context ExecutionContext, file string, preset *Element, filter FindFilter) (bool, error) {
+
+	path := strings.Split(filter.Key, ".")
+
+	if path[0] == "Preset" {
+		path = path[1:]
+	}
+
+	if len(path) == 0 {
+		return false, errors.New("invalid filter " + filter.Key)
+	}
+
+	gearType := path[len(path)-1]
+
+	if isGearType(gearType) && len(path) < 3 {
+		op := filter.Op
+		if op == "!=" {
+			op = "="
+		}
+		found := false
+		for _, gear := range presetGear(preset) {
+			if gear.Type != gearType || (len(path) == 2 && gear.Block != path[0]) {
+				continue
+			}
+			if compareValues(gear.Name, op, filter.Value) || compareValues(gear.GUID, op, filter.Value) {
+				found = true
+			}
+		}
+		return found != (filter.Op == "!="), nil
+	}
+
+	if len(path) == 1 && path[0] == "chain" {
+		chain := ""
+		if element := preset.child("Chain"); element != nil {
+			chain = element.attr("Preset")
+		}
+		return compareValues(chain, filter.Op, filter.Value), nil
+	}
+
+	if len(path) == 2 && path[0] == "db" {
+		if context.Database == nil {
+			return false, errors.New("preset database not found")
+		}
+		if strings.Trim(path[1], "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789") != "" {
+			return false, errors.New("invalid filter " + filter.Key)
+		}
+		// NULL compares as an empty value
+		var value sql.NullString
+		err := context.Database.QueryRow("select "+path[1]+" from pXcPresets where OriginalFileName = ?", file).Scan(&value)
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return compareValues(value.String, filter.Op, filter.Value), nil
+	}
+
+	if len(path) < 2 {
+		return false, errors.New("invalid filter " + filter.Key)
+	}
+
+	for _, element := range preset.findAll(path[:len(path)-1]) {
+		for _, attr := range element.matchAttrs(path[len(path)-1]) {
+			if compareValues(attr.Value, filter.Op, filter.Value) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func isGearType(name string) bool {
+	for _, gearType := range GearTypes {
+		if name == gearType {
+			return true
+		}
+	}
+	return false
+}
+
+func compareValues(actual string, op string, expected string) bool {
+	actualNumber, actualErr := strconv.ParseFloat(actual, 64)
+	expectedNumber, expectedErr := strconv.ParseFloat(expected, 64)
+	numeric := actualErr == nil && expectedErr == nil
+	switch op {
+	case "=":
+		return strings.EqualFold(actual, expected) || (numeric && actualNumber == expectedNumber)
+	case "!=":
+		return !strings.EqualFold(actual, expected) && !(numeric && actualNumber == expectedNumber)
+	case "~":
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(expected))
+	case ">":
+		return numeric && actualNumber > expectedNumber || !numeric && actual > expected
+	case ">=":
+		return numeric && actualNumber >= expectedNumber || !numeric && actual >= expected
+	case "<":
+		return numeric && actualNumber < expectedNumber || !numeric && actual < expected
+	case "<=":
+		return numeric && actualNumber <= expectedNumber || !numeric && actual <= expected
+	}
+	return false
+}
+
+func presetFolder(file string) string {
+	folder := filepath.Dir(file)
+	currPath := folder
+	exhausted := false
+	for !exhausted {
+		if filepath.Base(currPath) == PresetsFolder && isProfileFolder(filepath.Dir(currPath)) {
+			rel, _ := filepath.Rel(currPath, folder)
+			return rel
+		}
+		lastPath := currPath
+		currPath = filepath.Dir(currPath)
+		exhausted = lastPath == currPath
+	}
+	return folder
+}
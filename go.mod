@@ -5,4 +5,5 @@ go 1.15
 require (
 	github.com/google/uuid v1.1.5
 	github.com/mattn/go-sqlite3 v1.14.6
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -24,10 +24,20 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
 )
 
+var ConflictPolicies = []string{"skip", "overwrite", "rename", "newer"}
+
 func importPresets(context ExecutionContext) error {
 
+	if len(context.Args) < 2 {
+		return errors.New("import requires a source and a target profile")
+	}
+
 	sourcePath, _ := filepath.Abs(context.Args[0])
 	targetPath, _ := filepath.Abs(context.Args[1])
 
@@ -47,23 +57,77 @@ func importPresets(context ExecutionContext) error {
 		return errors.New("args cannot reference the same Amplitube profile")
 	}
 
-	matches, err := resolveToMatches(filepath.Join(sourceProfile, PresetsFolder), true, false)
+	policy := *context.Options["conflict"].(*string)
 
-	if err != nil {
-		return err
+	validPolicy := false
+
+	for _, conflictPolicy := range ConflictPolicies {
+		validPolicy = validPolicy || conflictPolicy == policy
 	}
 
-	importPath := filepath.Join(targetProfile, PresetsFolder, "Import")
-	var counter int
-	for info, _ := os.Stat(importPath); info != nil; info, _ = os.Stat(importPath) {
-		counter++
-		importPath = importPath + " - " + strconv.FormatInt(int64(counter), 10)
+	if !validPolicy {
+		return errors.New("unknown conflict policy " + policy + "; must be one of " + strings.Join(ConflictPolicies, ", "))
 	}
 
+	sourcePresets := filepath.Join(sourceProfile, PresetsFolder)
+	sourcePaths := []string{sourcePresets}
+	var filters []FindFilter
+
+	if len(context.Args) > 2 {
+		sourcePaths = nil
+	}
+
+	for _, where := range *context.Options["where"].(*[]string) {
+		filter, err := parseFindFilter(where)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+	}
+
+	for _, arg := range context.Args[2:] {
+		path := filepath.Join(sourcePresets, arg)
+		if !isSubDir(sourcePresets, path) {
+			return errors.New(arg + " is not in the source presets folder")
+		}
+		sourcePaths = append(sourcePaths, path)
+	}
+
+	if len(sourcePaths) == 0 {
+		sourcePaths = []string{sourcePresets}
+	}
+
+	var matches []string
+
+	for _, path := range sourcePaths {
+		found, err := resolveToMatches(path, true, false)
+		if err != nil {
+			return errors.New(path + ": " + err.Error())
+		}
+		if path != sourcePresets && isDir(path) {
+			matches = append(matches, path)
+		}
+		matches = append(matches, found...)
+	}
+
+	importPath := nextImportFolder(targetProfile)
+
+	if to := *context.Options["to"].(*string); to != "" {
+		importPath = to
+		if !filepath.IsAbs(to) {
+			importPath = filepath.Join(targetProfile, PresetsFolder, to)
+		}
+		if !isSubDir(filepath.Join(targetProfile, PresetsFolder), importPath) {
+			return errors.New(to + " is not in the target presets folder")
+		}
+	}
+
+	createdImportPath := !isDir(importPath)
+
 	files := map[string]string{}
 
 	for _, match := range matches {
-		files[match] = filepath.Join(importPath, match[len(sourceProfile)+len(PresetsFolder)+1:])
+		files[match] = filepath.Join(importPath, match[len(sourcePresets):])
 	}
 
 	sourceDatabase, err := openDatabase(filepath.Join(sourceProfile, "Presets.db"))
@@ -75,68 +139,132 @@ func importPresets(context ExecutionContext) error {
 	var sourceStmt *sql.Stmt
 
 	if !isV5Database(sourceDatabase) {
-		sourceStmt, err = sourceDatabase.Prepare("select userid, product, 0 as favorite, description, downloads, keywords, song, chaina, chainb, NULL as band, NULL as artist, NULL as atinstrumentstype, NULL as atpickuptype, NULL as atpickuppositions, NULL as atsoundcharacter, NULL as atgenre, songstructureelement, rating, madewith, chaintype, NULL as atinstrument from pXcPresets where substr(OriginalFileName, instr(OriginalFileName, '" + string(filepath.Separator) + "Presets" + string(filepath.Separator) + "')) = substr(?, instr(?, '" + string(filepath.Separator) + "Presets" + string(filepath.Separator) + "'))")
+		sourceStmt, err = sourceDatabase.Prepare("select userid, product, 0 as favorite, description, downloads, keywords, song, chaina, chainb, NULL as band, NULL as artist, NULL as atinstrumentstype, NULL as atpickuptype, NULL as atpickuppositions, NULL as atsoundcharacter, NULL as atgenre, songstructureelement, rating, madewith, chaintype, NULL as atinstrument from pXcPresets where " + presetsSuffixMatch)
 	} else {
-		sourceStmt, err = sourceDatabase.Prepare("select userid, product, favorite, description, downloads, keywords, song, chaina, chainb, band, artist, atinstrumentstype, atpickuptype, atpickuppositions, atsoundcharacter, atgenre, songstructureelement, rating, madewith, chaintype, atinstrument from pXcPresets where substr(OriginalFileName, instr(OriginalFileName, '" + string(filepath.Separator) + "Presets" + string(filepath.Separator) + "')) = substr(?, instr(?, '" + string(filepath.Separator) + "Presets" + string(filepath.Separator) + "'))")
+		sourceStmt, err = sourceDatabase.Prepare("select userid, product, favorite, description, downloads, keywords, song, chaina, chainb, band, artist, atinstrumentstype, atpickuptype, atpickuppositions, atsoundcharacter, atgenre, songstructureelement, rating, madewith, chaintype, atinstrument from pXcPresets where " + presetsSuffixMatch)
 	}
 
 	if err != nil {
 		return errors.New("Failed preparing statement: " + err.Error())
 	}
 
-	statement, err := context.Database.Prepare("insert into pXcPresets (UserId, Product, Favorite, Description, Downloads, Keywords, Song, ChainA, ChainB, Band, Artist, ATInstrumentsType, ATPickupType, ATPickupPositions, ATSoundCharacter, ATGenre, SongStructureElement, Rating, MadeWith, ChainType, ATInstrument, OriginalFileName, FileFolder, Name) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	statement, err := context.prepare("insert into pXcPresets (UserId, Product, Favorite, Description, Downloads, Keywords, Song, ChainA, ChainB, Band, Artist, ATInstrumentsType, ATPickupType, ATPickupPositions, ATSoundCharacter, ATGenre, SongStructureElement, Rating, MadeWith, ChainType, ATInstrument, OriginalFileName, FileFolder, Name) values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 
 	if err != nil {
 		return errors.New("Failed preparing statement: " + err.Error())
 	}
 
-	for source, target := range files {
+	filterContext := context
+	filterContext.Database = sourceDatabase
+
+	// the prior contents of each file written so a failed import into an
+	// existing folder can be undone; nil for files that did not exist
+	originals := map[string][]byte{}
+	var createdDirs []string
+
+	rollback := func() {
+		sourceStmt.Close()
+		sourceDatabase.Close()
+		statement.Close()
+		if createdImportPath {
+			rollbackImport(context, importPath)
+		} else {
+			rollbackImportFiles(context, originals, createdDirs)
+		}
+	}
+
+	for _, source := range sortMatches(matches) {
+
+		target := files[source]
 
 		if source == target {
 			continue
 		}
 
-		if isValidPresetFolderName(target) {
-			err = os.MkdirAll(target, 0775)
-		} else {
-			err = os.MkdirAll(filepath.Dir(target), 0775)
+		if isDir(source) && len(filters) > 0 {
+			continue
+		}
+
+		if !isDir(source) && len(filters) > 0 {
+			if !isValidPresetName(source) {
+				continue
+			}
+			found, err := matchImportFilters(filterContext, source, sourceRecordName(sourceDatabase, source), filters)
+			if err != nil {
+				rollback()
+				return err
+			}
+			if !found {
+				continue
+			}
 		}
 
+		dir := target
+
+		if !isDir(source) {
+			dir = filepath.Dir(target)
+		}
+
+		createdDirs = append(createdDirs, missingDirs(dir)...)
+
+		err = context.mkdirAll(dir, 0775)
+
 		if err != nil {
-			rollbackImport(importPath)
+			rollback()
 			return errors.New("Failed to created directories :" + err.Error())
 		}
 
-		if !isDir(target) {
+		if !isDir(source) {
+
+			if isFile(target) {
+				name, _ := filepath.Rel(importPath, target)
+				overwrite := policy == "overwrite"
+				if policy == "newer" {
+					overwrite = importTimestamp(sourceDatabase, sourceRecordName(sourceDatabase, source)).After(importTimestamp(context, target))
+				}
+				if policy == "rename" {
+					target = uniqueImportName(target)
+				} else if !overwrite && policy == "newer" {
+					fmt.Fprintln(out, "skipped "+name+"; existing preset is newer")
+					continue
+				} else if !overwrite {
+					fmt.Fprintln(out, "skipped "+name+"; already exists")
+					continue
+				} else if _, err = context.Exec("delete from pXcPresets where OriginalFileName = ?", target); err != nil {
+					rollback()
+					return errors.New("Copy failed.  Failed to update database: " + err.Error())
+				}
+			}
+
+			if _, ok := originals[target]; !ok && !context.DryRun {
+				originals[target], _ = ioutil.ReadFile(target)
+			}
 
 			data, err := ioutil.ReadFile(source)
 
 			if err != nil {
-				rollbackImport(importPath)
+				rollback()
 				return errors.New("Could not read source file: " + err.Error())
 			}
 
-			err = ioutil.WriteFile(target, data, 0644)
+			err = context.writeFile(target, data, 0644)
 
 			if err != nil {
-				rollbackImport(importPath)
+				rollback()
 				return errors.New("Could not write file: " + err.Error())
 			}
 
-			err = writeNewGuidToFile(target)
+			err = context.newGuid(target)
 
 			if err != nil {
-				rollbackImport(importPath)
+				rollback()
 				return errors.New("Failed to generate new GUID for copied file: " + err.Error())
 			}
 
 			rs, err := sourceStmt.Query(source, source)
 
 			if err != nil {
-				sourceStmt.Close()
-				sourceDatabase.Close()
-				statement.Close()
-				rollbackImport(importPath)
+				rollback()
 				return errors.New("Copy failed.  Failed to update database: " + err.Error())
 			}
 
@@ -152,10 +280,7 @@ func importPresets(context ExecutionContext) error {
 				_, err = statement.Exec(userid, product, favorite, description, downloads, keywords, song, chaina, chainb, band, artist, atinstrumentstype, atpickuptype, atpickuppositions, atsoundcharacter, atgenre, songstructureelement, rating, madewith, chaintype, atinstrument, target, filepath.Dir(target), makePresetPath(filepath.Base(target)))
 
 				if err != nil {
-					sourceStmt.Close()
-					sourceDatabase.Close()
-					statement.Close()
-					rollbackImport(importPath)
+					rollback()
 					return errors.New("Copy failed.  Failed to update database: " + err.Error())
 				}
 
@@ -174,6 +299,108 @@ func importPresets(context ExecutionContext) error {
 	return nil
 }
 
-func rollbackImport(importPath string) {
-	os.RemoveAll(importPath)
+// presetsSuffixMatch matches the OriginalFileName of a record from the
+// Presets folder on, so records of a profile that was moved or copied from
+// another machine are still found.
+var presetsSuffixMatch = "substr(OriginalFileName, instr(OriginalFileName, '" + string(filepath.Separator) + "Presets" + string(filepath.Separator) + "')) = substr(?, instr(?, '" + string(filepath.Separator) + "Presets" + string(filepath.Separator) + "'))"
+
+// sourceRecordName returns the OriginalFileName of the source database record
+// of a preset, or the file itself when it has no record.
+func sourceRecordName(database Executor, file string) string {
+	var name string
+	if database.QueryRow("select OriginalFileName from pXcPresets where "+presetsSuffixMatch, file, file).Scan(&name) != nil {
+		return file
+	}
+	return name
+}
+
+// matchImportFilters matches the preset file against the filters, with
+// database filters run against its record in the source database.
+func matchImportFilters(context ExecutionContext, file string, record string, filters []FindFilter) (bool, error) {
+	preset, err := readPresetFile(file)
+	if err != nil {
+		return false, nil
+	}
+	for _, filter := range filters {
+		found, err := matchFindFilter(context, record, preset, filter)
+		if err != nil || !found {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// importTimestamp returns the tstamp of the database record of a preset, or
+// the modification time of the file when it has no record.
+func importTimestamp(database Executor, file string) time.Time {
+	var tstamp interface{}
+	if database.QueryRow("select tstamp from pXcPresets where OriginalFileName = ?", file).Scan(&tstamp) == nil {
+		switch value := tstamp.(type) {
+		case time.Time:
+			return value
+		case string:
+			for _, format := range sqlite3.SQLiteTimestampFormats {
+				if parsed, err := time.ParseInLocation(format, value, time.UTC); err == nil {
+					return parsed
+				}
+			}
+		}
+	}
+	if info, err := os.Stat(file); err == nil {
+		return info.ModTime()
+	}
+	return time.Time{}
+}
+
+// uniqueImportName returns the first of Name - 1, Name - 2 ... not yet used
+// in the folder of the preset.
+func uniqueImportName(file string) string {
+	ext := filepath.Ext(file)
+	base := strings.TrimSuffix(file, ext)
+	target := file
+	for counter := 1; isFile(target); counter++ {
+		target = base + " - " + strconv.Itoa(counter) + ext
+	}
+	return target
+}
+
+// nextImportFolder returns the first of Import, Import - 1, Import - 2 ...
+// not yet in the presets folder of the profile.
+func nextImportFolder(profile string) string {
+	base := filepath.Join(profile, PresetsFolder, "Import")
+	importPath := base
+	for counter := 1; isDir(importPath) || isFile(importPath); counter++ {
+		importPath = base + " - " + strconv.Itoa(counter)
+	}
+	return importPath
+}
+
+func rollbackImport(context ExecutionContext, importPath string) {
+	context.removeAll(importPath)
+}
+
+func rollbackImportFiles(context ExecutionContext, originals map[string][]byte, createdDirs []string) {
+	if context.DryRun {
+		return
+	}
+	for file, data := range originals {
+		if data == nil {
+			context.remove(file)
+		} else {
+			context.writeFile(file, data, 0644)
+		}
+	}
+	for i := len(createdDirs) - 1; i >= 0; i-- {
+		context.removeAll(createdDirs[i])
+	}
+}
+
+// missingDirs returns the folders mkdirAll would create for a path, outermost
+// first.
+func missingDirs(path string) []string {
+	var dirs []string
+	for ; !isDir(path) && !isFile(path) && path != filepath.Dir(path); path = filepath.Dir(path) {
+		dirs = append([]string{path}, dirs...)
+	}
+	return dirs
 }
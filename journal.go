@@ -0,0 +1,490 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const AmptFolder = ".ampt"
+const JournalFolder = "journal"
+const JournalLimit = 50
+
+// A Journal records the prior state of everything a command changes so the
+// command can later be undone.  File changes are recorded by the
+// ExecutionContext operations and pXcPresets changes by temporary triggers
+// installed for the length of the transaction.
+type Journal struct {
+	Profile string
+	Entry   JournalEntry
+}
+
+type JournalEntry struct {
+	Id      int           `json:"-"`
+	Time    time.Time     `json:"time"`
+	Command string        `json:"command"`
+	Args    []string      `json:"args"`
+	Files   []JournalFile `json:"files,omitempty"`
+	Columns []string      `json:"columns,omitempty"`
+	Rows    []JournalRow  `json:"rows,omitempty"`
+}
+
+type JournalFile struct {
+	Op      string `json:"op"`
+	Path    string `json:"path"`
+	Target  string `json:"target,omitempty"`
+	Dir     bool   `json:"dir,omitempty"`
+	Existed bool   `json:"existed,omitempty"`
+	Data    []byte `json:"data,omitempty"`
+}
+
+type JournalRow struct {
+	Op     string `json:"op"`
+	Table  string `json:"table,omitempty"`
+	Column string `json:"column,omitempty"`
+	Id     int64  `json:"id"`
+	Values string `json:"values,omitempty"`
+}
+
+func newJournal(cmd string, args []string) *Journal {
+	return &Journal{Entry: JournalEntry{Time: time.Now(), Command: cmd, Args: args}}
+}
+
+func (j *Journal) locate(path string) {
+	if j.Profile != "" {
+		return
+	}
+	path, _ = filepath.Abs(path)
+	if profile, err := resolveToProfile(path); err == nil {
+		j.Profile = profile
+	}
+}
+
+func (j *Journal) recordFile(op string, file string) {
+	if j == nil {
+		return
+	}
+	j.locate(file)
+	record := JournalFile{Op: op, Path: file}
+	if info, err := os.Stat(file); err == nil {
+		record.Existed = true
+		record.Dir = info.IsDir()
+		if !record.Dir {
+			record.Data, _ = ioutil.ReadFile(file)
+		}
+	}
+	j.Entry.Files = append(j.Entry.Files, record)
+}
+
+func (j *Journal) recordMkdir(path string) {
+	if j == nil {
+		return
+	}
+	j.locate(path)
+	var created []string
+	for dir := path; !isDir(dir); dir = filepath.Dir(dir) {
+		created = append([]string{dir}, created...)
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+	for _, dir := range created {
+		j.Entry.Files = append(j.Entry.Files, JournalFile{Op: "mkdir", Path: dir, Dir: true})
+	}
+}
+
+func (j *Journal) recordRename(source string, target string) {
+	if j == nil {
+		return
+	}
+	j.locate(source)
+	record := JournalFile{Op: "rename", Path: source, Target: target}
+	if isFile(target) {
+		record.Existed = true
+		record.Data, _ = ioutil.ReadFile(target)
+	}
+	j.Entry.Files = append(j.Entry.Files, record)
+}
+
+func (j *Journal) recordRemoveAll(path string) {
+	if j == nil {
+		return
+	}
+	filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err == nil {
+			j.recordFile("remove", file)
+		}
+		return nil
+	})
+}
+
+// recordLookup records a row added to a lookup table such as pXcBands, which
+// the pXcPresets triggers do not see.  Column is the pXcPresets column
+// referring to the table.
+func (j *Journal) recordLookup(table string, column string, id int64) {
+	if j == nil {
+		return
+	}
+	j.Entry.Rows = append(j.Entry.Rows, JournalRow{Op: "insert", Table: table, Column: column, Id: id})
+}
+
+func (j *Journal) track(tx *sql.Tx) error {
+	if j == nil {
+		return nil
+	}
+
+	rows, err := tx.Query("select name from pragma_table_info('pXcPresets')")
+
+	if err != nil {
+		return err
+	}
+
+	var columns []string
+
+	for rows.Next() {
+		var column string
+		rows.Scan(&column)
+		columns = append(columns, column)
+	}
+
+	rows.Close()
+
+	if len(columns) == 0 {
+		return nil
+	}
+
+	var values []string
+
+	for _, column := range columns {
+		values = append(values, "quote(old."+column+")")
+	}
+
+	row := strings.Join(values, " || ',' || ")
+
+	for _, statement := range []string{
+		"create temp table if not exists journal_rows (seq integer primary key autoincrement, op text, id integer, row text)",
+		"create temp trigger journal_insert after insert on main.pXcPresets begin insert into journal_rows (op, id) values ('insert', new.Id); end",
+		"create temp trigger journal_update before update on main.pXcPresets begin insert into journal_rows (op, id, row) values ('update', old.Id, " + row + "); end",
+		"create temp trigger journal_delete before delete on main.pXcPresets begin insert into journal_rows (op, id, row) values ('delete', old.Id, " + row + "); end",
+	} {
+		if _, err = tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	j.Entry.Columns = columns
+
+	return nil
+}
+
+func (j *Journal) collect(tx *sql.Tx) error {
+	if j == nil || j.Entry.Columns == nil {
+		return nil
+	}
+
+	rows, err := tx.Query("select op, id, coalesce(row, '') from journal_rows order by seq")
+
+	if err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		var row JournalRow
+		if err = rows.Scan(&row.Op, &row.Id, &row.Values); err != nil {
+			rows.Close()
+			return err
+		}
+		j.Entry.Rows = append(j.Entry.Rows, row)
+	}
+
+	rows.Close()
+
+	for _, statement := range []string{
+		"drop trigger temp.journal_insert",
+		"drop trigger temp.journal_update",
+		"drop trigger temp.journal_delete",
+		"drop table temp.journal_rows",
+	} {
+		if _, err = tx.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (j *Journal) save(args []string) error {
+	if j == nil || (len(j.Entry.Files) == 0 && len(j.Entry.Rows) == 0) {
+		return nil
+	}
+
+	for _, arg := range args {
+		j.locate(arg)
+	}
+
+	if j.Profile == "" {
+		return nil
+	}
+
+	folder := journalFolder(j.Profile)
+
+	if err := os.MkdirAll(folder, 0775); err != nil {
+		return errors.New("failed to write journal: " + err.Error())
+	}
+
+	entries, _ := readJournal(j.Profile)
+
+	id := 1
+	if len(entries) > 0 {
+		id = entries[len(entries)-1].Id + 1
+	}
+
+	data, err := json.Marshal(j.Entry)
+
+	if err != nil {
+		return errors.New("failed to write journal: " + err.Error())
+	}
+
+	if err = ioutil.WriteFile(journalFile(j.Profile, id), data, 0664); err != nil {
+		return errors.New("failed to write journal: " + err.Error())
+	}
+
+	for len(entries) >= JournalLimit {
+		os.Remove(journalFile(j.Profile, entries[0].Id))
+		entries = entries[1:]
+	}
+
+	return nil
+}
+
+func journalFolder(profile string) string {
+	return filepath.Join(profile, AmptFolder, JournalFolder)
+}
+
+func journalFile(profile string, id int) string {
+	return filepath.Join(journalFolder(profile), fmt.Sprintf("%06d.json", id))
+}
+
+func readJournal(profile string) ([]JournalEntry, error) {
+
+	files, _ := filepath.Glob(filepath.Join(journalFolder(profile), "*.json"))
+
+	var entries []JournalEntry
+
+	for _, file := range files {
+
+		id, err := strconv.Atoi(strings.TrimSuffix(filepath.Base(file), ".json"))
+
+		if err != nil {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(file)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var entry JournalEntry
+
+		if err = json.Unmarshal(data, &entry); err != nil {
+			return nil, errors.New("invalid journal entry " + file + ": " + err.Error())
+		}
+
+		entry.Id = id
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, k int) bool {
+		return entries[i].Id < entries[k].Id
+	})
+
+	return entries, nil
+}
+
+func journalProfile(context ExecutionContext) (string, error) {
+	if len(context.Args) == 0 {
+		return "", errors.New("arg must be the root of an Amplitube profile")
+	}
+	source, _ := filepath.Abs(context.Args[0])
+	profile, err := resolveToProfile(source)
+	if err != nil {
+		return "", errors.New("arg must be the root of an Amplitube profile")
+	}
+	return profile, nil
+}
+
+func history(context ExecutionContext) error {
+
+	profile, err := journalProfile(context)
+
+	if err != nil {
+		return err
+	}
+
+	entries, err := readJournal(profile)
+
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "no operations recorded")
+		return nil
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		fmt.Fprintln(out, describeJournalEntry(entries[i]))
+	}
+
+	return nil
+}
+
+func undo(context ExecutionContext) error {
+
+	profile, err := journalProfile(context)
+
+	if err != nil {
+		return err
+	}
+
+	count := 1
+
+	if len(context.Args) > 1 {
+		count, err = strconv.Atoi(context.Args[1])
+		if err != nil || count < 1 {
+			return errors.New("invalid number of operations " + context.Args[1])
+		}
+	}
+
+	entries, err := readJournal(profile)
+
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		return errors.New("nothing to undo")
+	}
+
+	if count > len(entries) {
+		return errors.New("only " + strconv.Itoa(len(entries)) + " operation(s) to undo")
+	}
+
+	for i := len(entries) - 1; i >= len(entries)-count; i-- {
+
+		entry := entries[i]
+
+		if err = undoRows(context, entry); err != nil {
+			return errors.New("failed to undo " + describeJournalEntry(entry) + ": " + err.Error())
+		}
+
+		if err = undoFiles(context, entry); err != nil {
+			return errors.New("failed to undo " + describeJournalEntry(entry) + ": " + err.Error())
+		}
+
+		if !context.DryRun {
+			os.Remove(journalFile(profile, entry.Id))
+		}
+
+		fmt.Fprintln(out, "undid "+describeJournalEntry(entry))
+	}
+
+	return nil
+}
+
+func undoRows(context ExecutionContext, entry JournalEntry) error {
+
+	if len(entry.Rows) == 0 {
+		return nil
+	}
+
+	if context.Database == nil {
+		return errors.New("preset database not found")
+	}
+
+	for i := len(entry.Rows) - 1; i >= 0; i-- {
+		row := entry.Rows[i]
+		var err error
+		if row.Table != "" {
+			_, err = context.Exec("delete from "+row.Table+" where Id = ? and not exists (select 1 from pXcPresets where "+row.Column+" = ?)", row.Id, row.Id)
+		} else if row.Op == "insert" {
+			_, err = context.Exec("delete from pXcPresets where Id = ?", row.Id)
+		} else {
+			_, err = context.Exec("insert or replace into pXcPresets (" + strings.Join(entry.Columns, ", ") + ") values (" + row.Values + ")")
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func undoFiles(context ExecutionContext, entry JournalEntry) error {
+
+	for i := len(entry.Files) - 1; i >= 0; i-- {
+
+		file := entry.Files[i]
+		var err error
+
+		switch file.Op {
+		case "mkdir":
+			if isEmpty(file.Path, false) {
+				context.remove(file.Path)
+			}
+		case "rename":
+			err = context.rename(file.Target, file.Path)
+			if err == nil && file.Existed {
+				err = context.writeFile(file.Target, file.Data, 0664)
+			}
+		default:
+			if !file.Existed {
+				if isFile(file.Path) {
+					err = context.remove(file.Path)
+				}
+			} else if file.Dir {
+				err = context.mkdirAll(file.Path, 0775)
+			} else {
+				err = context.mkdirAll(filepath.Dir(file.Path), 0775)
+				if err == nil {
+					err = context.writeFile(file.Path, file.Data, 0664)
+				}
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func describeJournalEntry(entry JournalEntry) string {
+	return strconv.Itoa(entry.Id) + "  " + entry.Time.Format("2006-01-02 15:04:05") + "  " + strings.TrimSpace(entry.Command+" "+strings.Join(entry.Args, " "))
+}
@@ -0,0 +1,246 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+type LintIssue struct {
+	Severity string
+	Path     string
+	Message  string
+}
+
+type ParameterRange struct {
+	Element string
+	Attr    string
+	Min     float64
+	Max     float64
+}
+
+// ParameterRanges limits the values of attributes matching the element and
+// attribute name patterns when the gear catalog gives no range for them.  The
+// first matching range applies.
+var ParameterRanges = []ParameterRange{
+	{Element: "Amp", Attr: "*", Min: 0, Max: 10},
+	{Element: "*", Attr: "Bypass", Min: 0, Max: 1},
+	{Element: "*", Attr: "*Mute", Min: 0, Max: 1},
+	{Element: "*", Attr: "*Solo", Min: 0, Max: 1},
+	{Element: "*", Attr: "*Phase", Min: 0, Max: 1},
+	{Element: "*", Attr: "*Pan*", Min: -1, Max: 1},
+	{Element: "Cab", Attr: "Mic?Speaker", Min: 0, Max: 3},
+	{Element: "Cab", Attr: "RoomWidth", Min: 0, Max: 100},
+}
+
+func lint(context ExecutionContext) error {
+
+	if len(context.Args) < 1 {
+		return errors.New("lint requires a preset or folder")
+	}
+
+	recursive := *context.Options["recursive"].(*bool)
+
+	matches, err := resolveToMatches(context.Args[0], recursive, true)
+
+	if err != nil {
+		return err
+	}
+
+	count := 0
+
+	for _, match := range matches {
+		if !isValidPresetName(match) {
+			continue
+		}
+		for _, issue := range lintPreset(match) {
+			fmt.Fprintln(out, match+": "+issue.Severity+": "+issue.Path+": "+issue.Message)
+			count++
+		}
+	}
+
+	if count > 0 {
+		return errors.New(strconv.Itoa(count) + " issue(s) found")
+	}
+
+	return nil
+}
+
+func lintPreset(file string) []LintIssue {
+
+	preset, err := readPresetFile(file)
+
+	if err != nil {
+		return []LintIssue{{Severity: LintError, Path: "Preset", Message: err.Error()}}
+	}
+
+	schema, err := schemaForFormat(preset.attr("Format"))
+
+	if err != nil {
+		return []LintIssue{{Severity: LintError, Path: "Preset.Format", Message: err.Error()}}
+	}
+
+	var issues []LintIssue
+
+	// the model of the gear whose parameters each element holds
+	models := map[*Element]string{}
+
+	for _, block := range schema.Blocks {
+
+		element := preset.child(block.Name)
+
+		if element == nil {
+			issues = append(issues, LintIssue{LintError, "Preset." + block.Name, "block missing"})
+			continue
+		}
+
+		switch block.Kind {
+		case FxBlock:
+			issues = append(issues, lintFxBlock(element, block)...)
+			for _, slot := range fxSlots(element, block) {
+				models[slot.Slot] = slot.GUID
+			}
+		case AmpBlock:
+			issues = append(issues, lintModel(element, "Preset."+block.Name, "Model", Amps, "amp")...)
+			if params := element.child("Amp"); params != nil {
+				models[params] = element.attr("Model")
+			} else {
+				issues = append(issues, LintIssue{LintError, "Preset." + block.Name, "missing Amp element"})
+			}
+		case CabBlock:
+			issues = append(issues, lintModel(element, "Preset."+block.Name, "CabModel", Cabs, "cab")...)
+			models[element] = element.attr("CabModel")
+			for _, a := range element.matchAttrs("SpeakerModel*") {
+				issues = append(issues, lintModel(element, "Preset."+block.Name, a.Name.Local, Speakers, "speaker")...)
+			}
+			if cab := element.child("Cab"); cab != nil {
+				issues = append(issues, lintModel(cab, "Preset."+block.Name+".Cab", "Mic0Model", Mics, "mic")...)
+				issues = append(issues, lintModel(cab, "Preset."+block.Name+".Cab", "Mic1Model", Mics, "mic")...)
+				issues = append(issues, lintModel(cab, "Preset."+block.Name+".Cab", "RoomType", Rooms, "room")...)
+			}
+		}
+	}
+
+	return append(issues, lintRanges(preset, "Preset", models)...)
+}
+
+func lintFxBlock(element *Element, block BlockSchema) []LintIssue {
+
+	var issues []LintIssue
+
+	location := func(name string) string {
+		return "Preset." + block.Name + "." + name
+	}
+
+	for i := 0; i < block.Slots; i++ {
+
+		guid := element.attr(block.slotAttr(i))
+
+		if !element.hasAttr(block.slotAttr(i)) {
+			issues = append(issues, LintIssue{LintError, location(block.slotAttr(i)), "missing effect id"})
+		} else if !isEmptyFx(guid) && FX[guid] == "" {
+			issues = append(issues, LintIssue{LintWarning, location(block.slotAttr(i)), "unknown effect " + guid})
+		}
+
+		if element.child(slotName(i)) == nil {
+			severity := LintWarning
+			if !isEmptyFx(guid) {
+				severity = LintError
+			}
+			issues = append(issues, LintIssue{severity, location(slotName(i)), "missing slot element for " + getValueOrKey(FX, guid)})
+		}
+	}
+
+	for i := block.Slots; element.hasAttr(block.slotAttr(i)) || element.child(slotName(i)) != nil; i++ {
+		issues = append(issues, LintIssue{LintError, location(slotName(i)), block.Name + " has only " + strconv.Itoa(block.Slots) + " slots"})
+	}
+
+	return issues
+}
+
+func lintModel(element *Element, location string, attr string, models map[string]string, kind string) []LintIssue {
+	guid := element.attr(attr)
+	// Amplitube 4 writes speaker ids with dashes
+	if guid == "" || models[guid] != "" || models[strings.ReplaceAll(guid, "-", "")] != "" || isEmptyFx(guid) {
+		return nil
+	}
+	return []LintIssue{{LintWarning, location + "." + attr, "unknown " + kind + " " + guid}}
+}
+
+func lintRanges(element *Element, location string, models map[*Element]string) []LintIssue {
+
+	var issues []LintIssue
+
+	for _, a := range element.Attrs {
+		// model ids like AmpFlexiEqModel are not parameters
+		if _, err := uuid.Parse(a.Value); err == nil {
+			continue
+		}
+		r, ok := catalogRange(models[element], a.Name.Local)
+		if !ok {
+			r, ok = parameterRange(element.XMLName.Local, a.Name.Local)
+		}
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(a.Value, 64)
+		if err != nil {
+			issues = append(issues, LintIssue{LintWarning, location + "." + a.Name.Local, "value " + a.Value + " is not a number"})
+		} else if value < r.Min || value > r.Max {
+			issues = append(issues, LintIssue{LintWarning, location + "." + a.Name.Local, "value " + a.Value + " out of range " + formatNumber(r.Min) + " to " + formatNumber(r.Max)})
+		}
+	}
+
+	for _, c := range element.Children {
+		issues = append(issues, lintRanges(c, location+"."+c.XMLName.Local, models)...)
+	}
+
+	return issues
+}
+
+// catalogRange returns the range the gear catalog gives for a parameter of a
+// model.
+func catalogRange(guid string, name string) (ParameterRange, bool) {
+	for _, parameter := range GearParameters[guid] {
+		if parameter.Name == name && parameter.Min != nil && parameter.Max != nil {
+			return ParameterRange{Attr: name, Min: *parameter.Min, Max: *parameter.Max}, true
+		}
+	}
+	return ParameterRange{}, false
+}
+
+func parameterRange(element string, attr string) (ParameterRange, bool) {
+	for _, r := range ParameterRanges {
+		elementMatch, _ := path.Match(r.Element, element)
+		attrMatch, _ := path.Match(r.Attr, attr)
+		if elementMatch && attrMatch {
+			return r, true
+		}
+	}
+	return ParameterRange{}, false
+}
@@ -17,11 +17,22 @@ Copyright (C) 2021 fcbrooks
 package main
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 	"strings"
 )
 
+type PresetListing struct {
+	Folder string            `json:"folder"`
+	Name   string            `json:"name"`
+	Kind   string            `json:"kind"`
+	Path   string            `json:"path"`
+	Format string            `json:"format,omitempty"`
+	Meta   map[string]string `json:"meta,omitempty"`
+}
+
 func list(context ExecutionContext) error {
 
 	pathArg := "."
@@ -32,6 +43,8 @@ func list(context ExecutionContext) error {
 
 	showFullPath := *context.Options["fullpath"].(*bool)
 	recursive := *context.Options["recursive"].(*bool)
+	asJSON := *context.Options["json"].(*bool)
+	asCSV := *context.Options["csv"].(*bool)
 
 	matches, err := resolveToGroupedMatches(pathArg, recursive)
 
@@ -42,6 +55,22 @@ func list(context ExecutionContext) error {
 	rootPathLastIndex := len(filepath.Dir(commonBasePath(sortedKeys(matches)))) + 1
 	firstPath := true
 
+	if asJSON || asCSV {
+		listings, err := presetListings(context, matches, rootPathLastIndex)
+		if err != nil {
+			return err
+		}
+		if asCSV {
+			return writeListingsCSV(listings)
+		}
+		data, err := json.MarshalIndent(listings, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
 	for _, path := range sortedKeys(matches) {
 		if showFullPath {
 			for _, m := range matches[path] {
@@ -70,6 +99,85 @@ func list(context ExecutionContext) error {
 
 }
 
+func presetListings(context ExecutionContext, matches map[string][]string, rootPathLastIndex int) ([]PresetListing, error) {
+
+	listings := []PresetListing{}
+
+	for _, path := range sortedKeys(matches) {
+
+		if len(matches[path]) == 0 {
+			continue
+		}
+
+		if context.Database == nil {
+			context.Database = openProfileDatabase(path)
+			if context.Database != nil {
+				defer context.Database.Close()
+			}
+		}
+
+		for _, m := range matches[path] {
+
+			if !isDir(m) && !isValidPresetName(m) {
+				continue
+			}
+
+			listing := PresetListing{Folder: path[rootPathLastIndex:], Name: filepath.Base(m), Kind: "folder", Path: m}
+
+			if !isDir(m) {
+
+				preset, err := readPresetFile(m)
+
+				if err != nil {
+					return nil, err
+				}
+
+				listing.Name = listing.Name[:len(listing.Name)-5]
+				listing.Kind = "preset"
+				listing.Format = preset.attr("Format")
+				listing.Meta = map[string]string{}
+
+				for _, field := range MetaFields {
+					value, err := getMeta(context, m, preset, field)
+					if err != nil {
+						return nil, err
+					}
+					listing.Meta[field.Name] = value
+				}
+			}
+
+			listings = append(listings, listing)
+		}
+	}
+
+	return listings, nil
+}
+
+func writeListingsCSV(listings []PresetListing) error {
+
+	writer := csv.NewWriter(out)
+
+	header := []string{"Folder", "Name", "Kind", "Path", "Format"}
+
+	for _, field := range MetaFields {
+		header = append(header, field.Name)
+	}
+
+	writer.Write(header)
+
+	for _, listing := range listings {
+		record := []string{listing.Folder, listing.Name, listing.Kind, listing.Path, listing.Format}
+		for _, field := range MetaFields {
+			record = append(record, listing.Meta[field.Name])
+		}
+		writer.Write(record)
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
 func commonBasePath(paths []string) string {
 	commonPath := ""
 	found := false
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"errors"
 	"fmt"
@@ -18,6 +19,7 @@ func listGear(context ExecutionContext) error {
 
 	raw := *context.Options["raw"].(*bool)
 	details := *context.Options["details"].(*bool)
+	asJSON := *context.Options["json"].(*bool)
 
 	source, err := filepath.Abs(context.Args[0])
 
@@ -33,15 +35,33 @@ func listGear(context ExecutionContext) error {
 		return err
 	}
 
-	if raw || format != "at5p" {
+	schema, schemaErr := schemaForFormat(format)
+
+	if asJSON && !raw {
+
+		if schemaErr != nil {
+			return schemaErr
+		}
+
+		sourcePreset, err := parsePreset(sourceFile)
+
+		if err != nil {
+			return err
+		}
+
+		data, err := json.MarshalIndent(presetGearListing(filepath.Base(source), sourcePreset, schema), "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(out, string(data))
+
+	} else if raw || schemaErr != nil {
 		fmt.Fprintln(out, string(sourceFile))
 	} else {
 
-		var sourcePreset PresetXMLV5
-		err = xml.Unmarshal(sourceFile, &sourcePreset)
-
-		var cabAttrs CabAttrs
-		err = xml.Unmarshal(sourceFile, &cabAttrs)
+		sourcePreset, err := parsePreset(sourceFile)
 
 		if err != nil {
 			return err
@@ -54,69 +74,23 @@ func listGear(context ExecutionContext) error {
 
 		indent = append(indent, "    ")
 
-		if sourcePreset.Chain.Preset == "Chain11" {
-			printAmp(&output, fromAmpA(sourcePreset.AmpA), "AmpA", indent, details)
-			printCab(&output, fromCabA(sourcePreset.CabA), "CabA", indent, cabAttrs.CabA.Attrs, details)
-			printStomp(&output, fromStompA1(sourcePreset.StompA1), "StompA1", indent, details)
-			printStomp(&output, fromStompB1(sourcePreset.StompB1), "StompB1", indent, details)
-			printStomp(&output, fromLoopFxA(sourcePreset.LoopFxA), "LoopFxA", indent, details)
-			printStomp(&output, fromRackA(sourcePreset.RackA), "RackA", indent, details)
-			printStomp(&output, fromRackDI(sourcePreset.RackDI), "RackDI", indent, details)
-			printStomp(&output, fromRackMaster(sourcePreset.RackMaster), "RackMaster", indent, details)
-		}
-
-		if sourcePreset.Chain.Preset == "Chain12" {
-			printAmp(&output, fromAmpA(sourcePreset.AmpA), "AmpA", indent, details)
-			printCab(&output, fromCabA(sourcePreset.CabA), "CabA", indent, cabAttrs.CabA.Attrs, details)
-			printAmp(&output, fromAmpB(sourcePreset.AmpB), "AmpB", indent, details)
-			printCab(&output, fromCabB(sourcePreset.CabB), "CabB", indent, cabAttrs.CabB.Attrs, details)
-			printStomp(&output, fromStompA1(sourcePreset.StompA1), "StompA1", indent, details)
-			printStomp(&output, fromStompB1(sourcePreset.StompB1), "StompB1", indent, details)
-			printStomp(&output, fromStompB2(sourcePreset.StompB2), "StompB2", indent, details)
-			printStomp(&output, fromLoopFxA(sourcePreset.LoopFxA), "LoopFxA", indent, details)
-			printStomp(&output, fromLoopFxB(sourcePreset.LoopFxB), "LoopFxB", indent, details)
-			printStomp(&output, fromRackA(sourcePreset.RackA), "RackA", indent, details)
-			printStomp(&output, fromRackB(sourcePreset.RackB), "RackB", indent, details)
-			printStomp(&output, fromRackDI(sourcePreset.RackDI), "RackDI", indent, details)
-			printStomp(&output, fromRackMaster(sourcePreset.RackMaster), "RackMaster", indent, details)
-		}
-
-		if sourcePreset.Chain.Preset == "Chain13" {
-			printAmp(&output, fromAmpA(sourcePreset.AmpA), "AmpA", indent, details)
-			printCab(&output, fromCabA(sourcePreset.CabA), "CabA", indent, cabAttrs.CabA.Attrs, details)
-			printAmp(&output, fromAmpB(sourcePreset.AmpB), "AmpB", indent, details)
-			printCab(&output, fromCabB(sourcePreset.CabB), "CabB", indent, cabAttrs.CabB.Attrs, details)
-			printAmp(&output, fromAmpC(sourcePreset.AmpC), "AmpC", indent, details)
-			printCab(&output, fromCabC(sourcePreset.CabC), "CabC", indent, cabAttrs.CabC.Attrs, details)
-			printStomp(&output, fromStompA1(sourcePreset.StompA1), "StompA1", indent, details)
-			printStomp(&output, fromStompB1(sourcePreset.StompB1), "StompB1", indent, details)
-			printStomp(&output, fromStompB2(sourcePreset.StompB2), "StompB2", indent, details)
-			printStomp(&output, fromStompB3(sourcePreset.StompB3), "StompB3", indent, details)
-			printStomp(&output, fromLoopFxA(sourcePreset.LoopFxA), "LoopFxA", indent, details)
-			printStomp(&output, fromLoopFxB(sourcePreset.LoopFxB), "LoopFxB", indent, details)
-			printStomp(&output, fromLoopFxC(sourcePreset.LoopFxC), "LoopFxC", indent, details)
-			printStomp(&output, fromRackA(sourcePreset.RackA), "RackA", indent, details)
-			printStomp(&output, fromRackB(sourcePreset.RackB), "RackB", indent, details)
-			printStomp(&output, fromRackC(sourcePreset.RackC), "RackC", indent, details)
-			printStomp(&output, fromRackDI(sourcePreset.RackDI), "RackDI", indent, details)
-			printStomp(&output, fromRackMaster(sourcePreset.RackMaster), "RackMaster", indent, details)
-		}
+		chain := sourcePreset.child("Chain")
 
-		if sourcePreset.Chain.Preset == "Chain22" {
-			printAmp(&output, fromAmpA(sourcePreset.AmpA), "AmpA", indent, details)
-			printCab(&output, fromCabA(sourcePreset.CabA), "CabA", indent, cabAttrs.CabA.Attrs, details)
-			printAmp(&output, fromAmpB(sourcePreset.AmpB), "AmpB", indent, details)
-			printCab(&output, fromCabB(sourcePreset.CabB), "CabB", indent, cabAttrs.CabB.Attrs, details)
-			printStomp(&output, fromStompA1(sourcePreset.StompA1), "StompA1", indent, details)
-			printStomp(&output, fromStompA2(sourcePreset.StompA2), "StompA2", indent, details)
-			printStomp(&output, fromStompB1(sourcePreset.StompB1), "StompB1", indent, details)
-			printStomp(&output, fromStompB2(sourcePreset.StompB2), "StompB2", indent, details)
-			printStomp(&output, fromLoopFxA(sourcePreset.LoopFxA), "LoopFxA", indent, details)
-			printStomp(&output, fromLoopFxB(sourcePreset.LoopFxB), "LoopFxB", indent, details)
-			printStomp(&output, fromRackA(sourcePreset.RackA), "RackA", indent, details)
-			printStomp(&output, fromRackB(sourcePreset.RackB), "RackB", indent, details)
-			printStomp(&output, fromRackDI(sourcePreset.RackDI), "RackDI", indent, details)
-			printStomp(&output, fromRackMaster(sourcePreset.RackMaster), "RackMaster", indent, details)
+		if chain != nil {
+			for _, block := range schema.chainBlocks(chain.attr("Preset")) {
+				element := sourcePreset.child(block.Name)
+				if element == nil {
+					continue
+				}
+				switch block.Kind {
+				case AmpBlock:
+					printAmp(&output, element, indent, details)
+				case CabBlock:
+					printCab(&output, element, indent, details)
+				case FxBlock:
+					printStomp(&output, element, block, indent, details)
+				}
+			}
 		}
 
 		fmt.Fprintln(out, output)
@@ -126,139 +100,72 @@ func listGear(context ExecutionContext) error {
 	return nil
 }
 
-func printAmp(output *string, amp GenericAmp, ampType string, indent []string, details bool) {
-	*output = fmt.Sprintln(*output + strings.Join(indent, "") + ampType + ": " + getValueOrKey(Amps, amp.Model))
+func printAmp(output *string, amp *Element, indent []string, details bool) {
+	*output = fmt.Sprintln(*output + strings.Join(indent, "") + amp.XMLName.Local + ": " + getValueOrKey(Amps, amp.attr("Model")))
 	if details {
-		indent = append(indent, "    ")
-		for _, a := range amp.Amp.Attrs {
-			*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + a.Name.Local + ": " + a.Value)
+		if params := amp.child("Amp"); params != nil {
+			printAttrs(output, params, indent)
 		}
-		indent = indent[:len(indent)-1]
 	}
 }
 
-func printCab(output *string, cab GenericCab, cabType string, indent []string, attrs []xml.Attr, details bool) {
-	*output = fmt.Sprintln(*output + strings.Join(indent, "") + cabType + ": " + getValueOrKey(Cabs, cab.CabModel))
+func printCab(output *string, cab *Element, indent []string, details bool) {
+	cabModel := cab.attr("CabModel")
+	*output = fmt.Sprintln(*output + strings.Join(indent, "") + cab.XMLName.Local + ": " + getValueOrKey(Cabs, cabModel))
 	indent = append(indent, "    ")
-	for _, a := range attrs {
-		if details {
-			*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + a.Name.Local + ": " + a.Value)
-		} else {
-			if strings.Index(a.Name.Local, "SpeakerModel") == 0 {
-				speakerCount := 4
-				if SpeakerCount[cab.CabModel] != 0 {
-					speakerCount = SpeakerCount[cab.CabModel]
-				}
-				speakerNumber, _ := strconv.Atoi(a.Name.Local[12:])
-				if speakerNumber < speakerCount {
-					*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + a.Name.Local + ": " + getValueOrKey(Speakers, a.Value))
-				}
-			}
-		}
+	attrs := append([]xml.Attr{}, cab.Attrs...)
+	if params := cab.child("Cab"); params != nil {
+		attrs = append(attrs, params.Attrs...)
 	}
-	for _, a := range cab.Cab.Attrs {
-		if details {
-			if a.Name.Local == "Mic0Model" || a.Name.Local == "Mic1Model" {
-				*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + a.Name.Local + ": " + getValueOrKey(Mics, a.Value))
-			} else if strings.Index(a.Name.Local, "SpeakerModel") == 0 {
-				speakerCount := 4
-				if SpeakerCount[cab.CabModel] != 0 {
-					speakerCount = SpeakerCount[cab.CabModel]
-				}
-				speakerNumber, _ := strconv.Atoi(a.Name.Local[12:])
-				if speakerNumber < speakerCount {
-					*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + a.Name.Local + ": " + getValueOrKey(Speakers, a.Value))
-				}
-			} else {
-				*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + a.Name.Local + ": " + a.Value)
+	for _, a := range attrs {
+		name := a.Name.Local
+		if strings.Index(name, "SpeakerModel") == 0 {
+			speakerCount := 4
+			if SpeakerCount[cabModel] != 0 {
+				speakerCount = SpeakerCount[cabModel]
 			}
-		} else {
-			if a.Name.Local == "Mic0Model" || a.Name.Local == "Mic1Model" || a.Name.Local == "RoomType" || a.Name.Local == "RoomMicType" || strings.Index(a.Name.Local, "SpeakerModel") == 0 {
-				if a.Name.Local == "Mic0Model" || a.Name.Local == "Mic1Model" {
-					*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + a.Name.Local + ": " + getValueOrKey(Mics, a.Value))
-				} else if strings.Index(a.Name.Local, "SpeakerModel") == 0 {
-					speakerCount := 4
-					if SpeakerCount[cab.CabModel] != 0 {
-						speakerCount = SpeakerCount[cab.CabModel]
-					}
-					speakerNumber, _ := strconv.Atoi(a.Name.Local[12:])
-					if speakerNumber < speakerCount {
-						*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + a.Name.Local + ": " + getValueOrKey(Speakers, a.Value))
-					}
-				} else {
-					*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + a.Name.Local + ": " + a.Value)
-				}
+			speakerNumber, _ := strconv.Atoi(name[12:])
+			if speakerNumber < speakerCount {
+				*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + name + ": " + getValueOrKey(Speakers, a.Value))
 			}
+		} else if name == "Mic0Model" || name == "Mic1Model" {
+			*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + name + ": " + getValueOrKey(Mics, a.Value))
+		} else if details || name == "RoomType" || name == "RoomMicType" {
+			*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + name + ": " + a.Value)
 		}
-
 	}
-	indent = indent[:len(indent)-1]
 }
 
-func printStomp(output *string, stomp GenericStomp, stompType string, indent []string, details bool) {
-	if !allStompsEmpty(stomp) {
-		*output = fmt.Sprintln(*output + strings.Join(indent, "") + stompType)
+func printStomp(output *string, stomp *Element, block BlockSchema, indent []string, details bool) {
+	slots := fxSlots(stomp, block)
+	if !allStompsEmpty(slots) {
+		*output = fmt.Sprintln(*output + strings.Join(indent, "") + stomp.XMLName.Local)
 		indent = append(indent, "    ")
-		for i := 0; i < stomp.StompCount; i++ {
-			switch i {
-			case 0:
-				if stomp.Stomp0 != EmptySlotGUID {
-					*output = fmt.Sprintln(*output + strings.Join(indent, "") + "Slot" + strconv.Itoa(i) + ": " + getValueOrKey(FX, stomp.Stomp0))
-					if details {
-						printSlot(output, GenericSlot{stomp.Slot0.Attrs}, "Slot0", indent)
-					}
-				}
-			case 1:
-				if stomp.Stomp1 != EmptySlotGUID {
-					*output = fmt.Sprintln(*output + strings.Join(indent, "") + "Slot" + strconv.Itoa(i) + ": " + getValueOrKey(FX, stomp.Stomp1))
-					if details {
-						printSlot(output, GenericSlot{stomp.Slot1.Attrs}, "Slot"+strconv.Itoa(i), indent)
-					}
-				}
-			case 2:
-				if stomp.Stomp2 != EmptySlotGUID {
-					*output = fmt.Sprintln(*output + strings.Join(indent, "") + "Slot" + strconv.Itoa(i) + ": " + getValueOrKey(FX, stomp.Stomp2))
-					if details {
-						printSlot(output, GenericSlot{stomp.Slot1.Attrs}, "Slot"+strconv.Itoa(i), indent)
-					}
-				}
-			case 3:
-				if stomp.Stomp3 != EmptySlotGUID {
-					*output = fmt.Sprintln(*output + strings.Join(indent, "") + "Slot" + strconv.Itoa(i) + ": " + getValueOrKey(FX, stomp.Stomp3))
-					if details {
-						printSlot(output, GenericSlot{stomp.Slot1.Attrs}, "Slot"+strconv.Itoa(i), indent)
-					}
-				}
-			case 4:
-				if stomp.Stomp4 != EmptySlotGUID {
-					*output = fmt.Sprintln(*output + strings.Join(indent, "") + "Slot" + strconv.Itoa(i) + ": " + getValueOrKey(FX, stomp.Stomp4))
-					if details {
-						printSlot(output, GenericSlot{stomp.Slot1.Attrs}, "Slot"+strconv.Itoa(i), indent)
-					}
-				}
-			case 5:
-				if stomp.Stomp5 != EmptySlotGUID {
-					*output = fmt.Sprintln(*output + strings.Join(indent, "") + "Slot" + strconv.Itoa(i) + ": " + getValueOrKey(FX, stomp.Stomp5))
-					if details {
-						printSlot(output, GenericSlot{stomp.Slot1.Attrs}, "Slot"+strconv.Itoa(i), indent)
-					}
+		for i, slot := range slots {
+			if !isEmptyFx(slot.GUID) {
+				*output = fmt.Sprintln(*output + strings.Join(indent, "") + slotName(i) + ": " + getValueOrKey(FX, slot.GUID))
+				if details {
+					printAttrs(output, slot.Slot, indent)
 				}
 			}
 		}
-		indent = indent[:len(indent)-1]
 	}
 }
 
-func printSlot(output *string, slot GenericSlot, slotType string, indent []string) {
+func printAttrs(output *string, element *Element, indent []string) {
 	indent = append(indent, "    ")
-	for _, a := range slot.Attrs {
+	for _, a := range element.Attrs {
 		*output = fmt.Sprintln(*output + strings.Join(indent, "") + " " + a.Name.Local + ": " + a.Value)
 	}
-	indent = indent[:len(indent)-1]
 }
 
-func allStompsEmpty(stomp GenericStomp) bool {
-	return (stomp.Stomp0 == EmptySlotGUID || stomp.Stomp0 == "") && (stomp.Stomp1 == EmptySlotGUID || stomp.Stomp1 == "") && (stomp.Stomp2 == EmptySlotGUID || stomp.Stomp2 == "") && (stomp.Stomp3 == EmptySlotGUID || stomp.Stomp3 == "") && (stomp.Stomp4 == EmptySlotGUID || stomp.Stomp4 == "") && (stomp.Stomp5 == EmptySlotGUID || stomp.Stomp5 == "")
+func allStompsEmpty(slots []FxSlot) bool {
+	for _, slot := range slots {
+		if !isEmptyFx(slot.GUID) {
+			return false
+		}
+	}
+	return true
 }
 
 func getValueOrKey(valueMap map[string]string, key string) string {
@@ -268,3 +175,129 @@ func getValueOrKey(valueMap map[string]string, key string) string {
 	}
 	return value
 }
+
+type GearListing struct {
+	Preset string      `json:"preset"`
+	Format string      `json:"format"`
+	Chain  string      `json:"chain,omitempty"`
+	Blocks []GearBlock `json:"blocks"`
+}
+
+type GearBlock struct {
+	Block      string     `json:"block"`
+	Kind       string     `json:"kind"`
+	Active     bool       `json:"active"`
+	GUID       string     `json:"guid,omitempty"`
+	Model      string     `json:"model,omitempty"`
+	Category   string     `json:"category,omitempty"`
+	Attributes DocAttrs   `json:"attributes,omitempty"`
+	Parameters DocAttrs   `json:"parameters,omitempty"`
+	Parts      []GearPart `json:"parts,omitempty"`
+	Slots      []GearSlot `json:"slots,omitempty"`
+}
+
+type GearPart struct {
+	Type     string `json:"type"`
+	Attr     string `json:"attr"`
+	GUID     string `json:"guid"`
+	Model    string `json:"model,omitempty"`
+	Category string `json:"category,omitempty"`
+}
+
+type GearSlot struct {
+	Slot       int      `json:"slot"`
+	GUID       string   `json:"guid"`
+	Model      string   `json:"model,omitempty"`
+	Category   string   `json:"category,omitempty"`
+	Attributes DocAttrs `json:"attributes,omitempty"`
+}
+
+func presetGearListing(name string, preset *Element, schema PresetSchema) GearListing {
+
+	listing := GearListing{Preset: name, Format: schema.Format, Blocks: []GearBlock{}}
+
+	active := map[string]bool{}
+
+	if chain := preset.child("Chain"); chain != nil {
+		listing.Chain = chain.attr("Preset")
+		for _, block := range schema.chainBlocks(listing.Chain) {
+			active[block.Name] = true
+		}
+	}
+
+	for _, block := range schema.Blocks {
+
+		element := preset.child(block.Name)
+
+		if element == nil {
+			continue
+		}
+
+		gear := GearBlock{Block: block.Name, Active: active[block.Name] || block.Kind == SettingsBlock}
+
+		switch block.Kind {
+		case AmpBlock:
+			gear.Kind = "amp"
+			gear.GUID = element.attr("Model")
+			gear.Model = Amps[gear.GUID]
+			gear.Category = catalogCategory("amps", gear.GUID)
+			gear.Attributes = DocAttrs(element.Attrs)
+			if params := element.child("Amp"); params != nil {
+				gear.Parameters = DocAttrs(params.Attrs)
+			}
+		case CabBlock:
+			gear.Kind = "cab"
+			gear.GUID = element.attr("CabModel")
+			gear.Model = Cabs[gear.GUID]
+			gear.Category = catalogCategory("cabs", gear.GUID)
+			gear.Attributes = DocAttrs(element.Attrs)
+			if params := element.child("Cab"); params != nil {
+				gear.Parameters = DocAttrs(params.Attrs)
+			}
+			gear.Parts = cabParts(element)
+		case FxBlock:
+			gear.Kind = "fx"
+			for _, attr := range element.Attrs {
+				if !block.isSlotAttr(attr.Name.Local) {
+					gear.Attributes = append(gear.Attributes, attr)
+				}
+			}
+			for i, slot := range fxSlots(element, block) {
+				if isEmptyFx(slot.GUID) {
+					continue
+				}
+				gear.Slots = append(gear.Slots, GearSlot{Slot: i, GUID: slot.GUID, Model: FX[slot.GUID], Category: catalogCategory("fx", slot.GUID), Attributes: DocAttrs(slot.Slot.Attrs)})
+			}
+		default:
+			gear.Kind = "settings"
+			gear.Attributes = DocAttrs(element.Attrs)
+		}
+
+		listing.Blocks = append(listing.Blocks, gear)
+	}
+
+	return listing
+}
+
+func cabParts(cab *Element) []GearPart {
+	var parts []GearPart
+	speakerCount := 4
+	if SpeakerCount[cab.attr("CabModel")] != 0 {
+		speakerCount = SpeakerCount[cab.attr("CabModel")]
+	}
+	for _, a := range cab.Attrs {
+		if strings.Index(a.Name.Local, "SpeakerModel") == 0 {
+			if n, _ := strconv.Atoi(a.Name.Local[12:]); n < speakerCount {
+				parts = append(parts, GearPart{Type: "speaker", Attr: a.Name.Local, GUID: a.Value, Model: Speakers[a.Value], Category: catalogCategory("speakers", a.Value)})
+			}
+		}
+	}
+	if params := cab.child("Cab"); params != nil {
+		for _, name := range []string{"Mic0Model", "Mic1Model"} {
+			if params.hasAttr(name) {
+				parts = append(parts, GearPart{Type: "mic", Attr: name, GUID: params.attr(name), Model: Mics[params.attr(name)], Category: catalogCategory("mics", params.attr(name))})
+			}
+		}
+	}
+	return parts
+}
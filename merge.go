@@ -0,0 +1,274 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strconv"
+)
+
+type MergeConflict struct {
+	Path   string
+	Base   string
+	Ours   string
+	Theirs string
+}
+
+func merge(context ExecutionContext) error {
+
+	if len(context.Args) < 3 {
+		return errors.New("merge requires a base, ours and theirs preset")
+	}
+
+	output := *context.Options["output"].(*string)
+
+	var files []string
+
+	for _, arg := range context.Args[:3] {
+		file, _ := filepath.Abs(arg)
+		if !isFile(file) {
+			return errors.New("merge arguments must be preset files")
+		}
+		files = append(files, file)
+	}
+
+	var presets []*Element
+
+	for _, file := range files {
+		preset, err := readPresetFile(file)
+		if err != nil {
+			return err
+		}
+		presets = append(presets, preset)
+	}
+
+	result, conflicts := mergePresets(presets[0], presets[1], presets[2])
+
+	if output == "" {
+		output = files[1]
+	}
+
+	if err := context.writePreset(output, result); err != nil {
+		return err
+	}
+
+	for _, conflict := range conflicts {
+		fmt.Fprintln(out, "CONFLICT "+conflict.Path+": base "+conflict.Base+", ours "+conflict.Ours+", theirs "+conflict.Theirs)
+	}
+
+	if len(conflicts) > 0 {
+		return errors.New(strconv.Itoa(len(conflicts)) + " merge conflict(s); kept our values")
+	}
+
+	return nil
+}
+
+func mergePresets(base *Element, ours *Element, theirs *Element) (*Element, []MergeConflict) {
+
+	var conflicts []MergeConflict
+
+	schema, err := schemaForFormat(ours.attr("Format"))
+
+	if err != nil || ours.attr("Format") != theirs.attr("Format") {
+		return mergeElements("Preset", base, ours, theirs, &conflicts), conflicts
+	}
+
+	result := &Element{XMLName: ours.XMLName, Attrs: mergeAttrs("Preset", base, ours, theirs, nil, &conflicts)}
+
+	for _, pair := range pairChildren(ours, theirs) {
+		baseChild := nthChild(base, pair.Local, pair.Occurrence)
+		block, ok := schema.block(pair.Local)
+		if ok && block.Kind == FxBlock && pair.A != nil && pair.B != nil && baseChild != nil {
+			result.Children = append(result.Children, mergeFxBlock(pair.Name, block, baseChild, pair.A, pair.B, &conflicts))
+		} else if child := mergeChild(pair.Name, baseChild, pair.A, pair.B, &conflicts); child != nil {
+			result.Children = append(result.Children, child)
+		}
+	}
+
+	return result, conflicts
+}
+
+func mergeChild(path string, base *Element, ours *Element, theirs *Element, conflicts *[]MergeConflict) *Element {
+	switch {
+	case ours != nil && theirs != nil:
+		return mergeElements(path, base, ours, theirs, conflicts)
+	case ours == nil && theirs == nil:
+		return nil
+	case ours == nil:
+		if base == nil {
+			return theirs.clone()
+		}
+		if !elementsEqual(base, theirs) {
+			*conflicts = append(*conflicts, MergeConflict{Path: path, Base: "present", Ours: "removed", Theirs: "modified"})
+		}
+		return nil
+	default:
+		if base == nil {
+			return ours.clone()
+		}
+		if !elementsEqual(base, ours) {
+			*conflicts = append(*conflicts, MergeConflict{Path: path, Base: "present", Ours: "modified", Theirs: "removed"})
+			return ours.clone()
+		}
+		return nil
+	}
+}
+
+func mergeElements(path string, base *Element, ours *Element, theirs *Element, conflicts *[]MergeConflict) *Element {
+
+	if elementsEqual(ours, theirs) {
+		return ours.clone()
+	}
+
+	if base != nil && elementsEqual(base, ours) {
+		return theirs.clone()
+	}
+
+	if base != nil && elementsEqual(base, theirs) {
+		return ours.clone()
+	}
+
+	result := &Element{XMLName: ours.XMLName, Attrs: mergeAttrs(path, base, ours, theirs, nil, conflicts)}
+
+	for _, pair := range pairChildren(ours, theirs) {
+		var baseChild *Element
+		if base != nil {
+			baseChild = nthChild(base, pair.Local, pair.Occurrence)
+		}
+		if child := mergeChild(path+"."+pair.Name, baseChild, pair.A, pair.B, conflicts); child != nil {
+			result.Children = append(result.Children, child)
+		}
+	}
+
+	return result
+}
+
+func mergeFxBlock(path string, block BlockSchema, base *Element, ours *Element, theirs *Element, conflicts *[]MergeConflict) *Element {
+
+	var ignore []string
+	for i := 0; i < block.Slots; i++ {
+		ignore = append(ignore, block.slotAttr(i))
+	}
+
+	result := ours.clone()
+	result.Attrs = mergeAttrs(path, base, ours, theirs, ignore, conflicts)
+
+	baseSlots := fxSlots(base, block)
+	ourSlots := fxSlots(ours, block)
+	theirSlots := fxSlots(theirs, block)
+
+	var slots []FxSlot
+
+	for i := range ourSlots {
+		b, o, t := baseSlots[i], ourSlots[i], theirSlots[i]
+		switch {
+		case fxSlotsEqual(o, t) || fxSlotsEqual(b, t):
+			slots = append(slots, o)
+		case fxSlotsEqual(b, o):
+			slots = append(slots, t)
+		case o.GUID == t.GUID:
+			var baseSlot *Element
+			if b.GUID == o.GUID {
+				baseSlot = b.Slot
+			}
+			slots = append(slots, FxSlot{GUID: o.GUID, Slot: mergeElements(path+"."+slotName(i), baseSlot, o.Slot, t.Slot, conflicts)})
+		default:
+			*conflicts = append(*conflicts, MergeConflict{Path: path + "." + slotName(i), Base: fxDisplayName(b.GUID), Ours: fxDisplayName(o.GUID), Theirs: fxDisplayName(t.GUID)})
+			slots = append(slots, o)
+		}
+	}
+
+	setFxSlots(result, block, slots)
+
+	return result
+}
+
+func mergeAttrs(path string, base *Element, ours *Element, theirs *Element, ignore []string, conflicts *[]MergeConflict) []xml.Attr {
+
+	skip := map[string]bool{}
+	for _, name := range ignore {
+		skip[name] = true
+	}
+
+	var names []string
+	for _, attr := range ours.Attrs {
+		names = append(names, attr.Name.Local)
+	}
+	for _, attr := range theirs.Attrs {
+		if !ours.hasAttr(attr.Name.Local) {
+			names = append(names, attr.Name.Local)
+		}
+	}
+
+	var attrs []xml.Attr
+
+	for _, name := range names {
+		o, hasO := ours.attr(name), ours.hasAttr(name)
+		t, hasT := theirs.attr(name), theirs.hasAttr(name)
+		b, hasB := "", false
+		if base != nil {
+			b, hasB = base.attr(name), base.hasAttr(name)
+		}
+		value, has := o, hasO
+		switch {
+		case skip[name] || (hasO == hasT && o == t):
+		case hasB == hasO && b == o:
+			value, has = t, hasT
+		case hasB == hasT && b == t:
+		default:
+			*conflicts = append(*conflicts, MergeConflict{Path: path + "." + name, Base: mergeDisplayValue(name, b, hasB), Ours: mergeDisplayValue(name, o, hasO), Theirs: mergeDisplayValue(name, t, hasT)})
+			if !hasO {
+				value, has = t, hasT
+			}
+		}
+		if has {
+			attrs = append(attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+		}
+	}
+
+	return attrs
+}
+
+func mergeDisplayValue(name string, value string, has bool) string {
+	if !has {
+		return "(none)"
+	}
+	return attrDisplayValue(name, value)
+}
+
+func fxDisplayName(guid string) string {
+	if isEmptyFx(guid) {
+		return "(empty)"
+	}
+	return getValueOrKey(FX, guid)
+}
+
+func fxSlotsEqual(a FxSlot, b FxSlot) bool {
+	if isEmptyFx(a.GUID) && isEmptyFx(b.GUID) {
+		return true
+	}
+	return a.GUID == b.GUID && elementsEqual(a.Slot, b.Slot)
+}
+
+func elementsEqual(a *Element, b *Element) bool {
+	aData, aErr := xml.Marshal(a)
+	bData, bErr := xml.Marshal(b)
+	return aErr == nil && bErr == nil && string(aData) == string(bData)
+}
@@ -0,0 +1,285 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"database/sql"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+type MetaField struct {
+	Name   string
+	Attr   string
+	Column string
+	Table  string
+}
+
+var MetaFields = []MetaField{
+	{Name: "Description", Attr: "Description", Column: "Description"},
+	{Name: "Style", Attr: "Style", Column: "ATGenre", Table: "pXcGenre"},
+	{Name: "SoundCharacter", Attr: "SoundCharacter", Column: "ATSoundCharacter", Table: "pXcATSoundCharacters"},
+	{Name: "Instrument", Attr: "Instrument", Column: "ATInstrument", Table: "pXcATInstrument"},
+	{Name: "Body", Attr: "Body", Column: "ATInstrumentsType", Table: "pXcATInstrumentsType"},
+	{Name: "PickUpPosition", Attr: "PickUpPosition", Column: "ATPickupPositions", Table: "pXcPickupPositions"},
+	{Name: "Type", Attr: "Type", Column: "ATPickupType", Table: "pXcATPickupType"},
+	{Name: "Artist", Attr: "Artist", Column: "Artist", Table: "pXcArtists"},
+	{Name: "Band", Attr: "Band", Column: "Band", Table: "pXcBands"},
+	{Name: "Song", Attr: "Song", Column: "Song"},
+	{Name: "SongStructureElement", Attr: "SongStructureElement", Column: "SongStructureElement", Table: "pXcSongStructureElements"},
+	{Name: "KeyWords", Attr: "KeyWords", Column: "Keywords"},
+	{Name: "Favorite", Column: "Favorite"},
+	{Name: "Rating", Column: "Rating"},
+	{Name: "MadeWith", Column: "MadeWith"},
+}
+
+type MetaAssignment struct {
+	Field MetaField
+	Value string
+}
+
+func meta(context ExecutionContext) error {
+
+	if len(context.Args) < 1 {
+		return errors.New("meta requires a preset or folder")
+	}
+
+	recursive := *context.Options["recursive"].(*bool)
+
+	var fields []MetaField
+	var assignments []MetaAssignment
+
+	for _, arg := range context.Args[1:] {
+		nameValuePair := strings.SplitN(arg, "=", 2)
+		field, ok := lookupMetaField(nameValuePair[0])
+		if !ok {
+			return errors.New("unknown metadata field " + nameValuePair[0])
+		}
+		if len(nameValuePair) == 2 {
+			assignments = append(assignments, MetaAssignment{Field: field, Value: nameValuePair[1]})
+		} else {
+			fields = append(fields, field)
+		}
+	}
+
+	if len(fields) > 0 && len(assignments) > 0 {
+		return errors.New("cannot mix getting and setting metadata")
+	}
+
+	if len(fields) == 0 {
+		fields = MetaFields
+	}
+
+	matches, err := resolveToMatches(context.Args[0], recursive, true)
+
+	if err != nil {
+		return err
+	}
+
+	if len(assignments) > 0 {
+		return setMeta(context, matches, assignments)
+	}
+
+	for _, match := range sortMatches(matches) {
+
+		if !isValidPresetName(match) {
+			continue
+		}
+
+		preset, err := readPresetFile(match)
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintln(out, filepath.Base(match))
+
+		for _, field := range fields {
+			value, err := getMeta(context, match, preset, field)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, "    "+field.Name+": "+value)
+		}
+
+	}
+
+	return nil
+}
+
+func setMeta(context ExecutionContext, matches []string, assignments []MetaAssignment) error {
+
+	if context.Database == nil {
+		return errors.New("preset database not found")
+	}
+
+	originals := map[string][]byte{}
+
+	for _, match := range matches {
+
+		if !isValidPresetName(match) {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(match)
+
+		if err != nil {
+			rollbackMeta(context, originals)
+			return err
+		}
+
+		preset, err := parsePreset(data)
+
+		if err != nil {
+			rollbackMeta(context, originals)
+			return err
+		}
+
+		var id int64
+
+		hasRecord := context.QueryRow("select Id from pXcPresets where OriginalFileName = ?", match).Scan(&id) == nil
+
+		if !hasRecord {
+			fmt.Fprintln(out, match+" has no database record")
+		}
+
+		for _, assignment := range assignments {
+
+			field := assignment.Field
+
+			if field.Attr != "" {
+				metaInfo := preset.child("MetaInfo")
+				if metaInfo == nil {
+					metaInfo = &Element{XMLName: xml.Name{Local: "MetaInfo"}}
+					preset.Children = append(preset.Children, metaInfo)
+				}
+				metaInfo.putAttr(field.Attr, assignment.Value)
+			}
+
+			if field.Column != "" && hasRecord {
+				value, err := metaColumnValue(context, field, assignment.Value)
+				if err != nil {
+					rollbackMeta(context, originals)
+					return err
+				}
+				if _, err = context.Exec("update pXcPresets set "+field.Column+" = ? where Id = ?", value, id); err != nil {
+					rollbackMeta(context, originals)
+					return errors.New("Failed to update database: " + err.Error())
+				}
+			}
+
+		}
+
+		originals[match] = data
+
+		if err = context.writePreset(match, preset); err != nil {
+			rollbackMeta(context, originals)
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func getMeta(context ExecutionContext, file string, preset *Element, field MetaField) (string, error) {
+
+	if field.Attr != "" {
+		if metaInfo := preset.child("MetaInfo"); metaInfo != nil {
+			return metaInfo.attr(field.Attr), nil
+		}
+	}
+
+	if context.Database == nil || field.Column == "" {
+		return "", nil
+	}
+
+	query := "select " + field.Column + " from pXcPresets where OriginalFileName = ?"
+
+	if field.Table != "" {
+		query = "select t.Description from pXcPresets p left join " + field.Table + " t on t.Id = p." + field.Column + " where p.OriginalFileName = ?"
+	}
+
+	var value interface{}
+
+	if err := context.QueryRow(query, file).Scan(&value); err != nil && err != sql.ErrNoRows {
+		return "", err
+	}
+
+	if value == nil {
+		return "", nil
+	}
+
+	if data, ok := value.([]byte); ok {
+		return string(data), nil
+	}
+
+	return fmt.Sprint(value), nil
+}
+
+func metaColumnValue(context ExecutionContext, field MetaField, value string) (interface{}, error) {
+
+	if field.Table == "" {
+		return value, nil
+	}
+
+	if value == "" {
+		return nil, nil
+	}
+
+	var id int64
+
+	err := context.QueryRow("select Id from "+field.Table+" where Description = ? collate nocase", value).Scan(&id)
+
+	if err == sql.ErrNoRows {
+		result, err := context.Exec("insert into "+field.Table+" (Id, Description) select coalesce(max(Id), 0) + 1, ? from "+field.Table, value)
+		if err != nil {
+			return nil, errors.New("Failed to add " + value + " to " + field.Table + ": " + err.Error())
+		}
+		id, err = result.LastInsertId()
+		if err != nil {
+			return nil, err
+		}
+		context.Journal.recordLookup(field.Table, field.Column, id)
+		return id, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return id, nil
+}
+
+func lookupMetaField(name string) (MetaField, bool) {
+	for _, field := range MetaFields {
+		if strings.EqualFold(field.Name, name) || strings.EqualFold(field.Column, name) {
+			return field, true
+		}
+	}
+	return MetaField{}, false
+}
+
+func rollbackMeta(context ExecutionContext, originals map[string][]byte) {
+	for file, data := range originals {
+		context.writeFile(file, data, 0664)
+	}
+}
@@ -18,7 +18,6 @@ package main
 
 import (
 	"errors"
-	"os"
 	"path/filepath"
 )
 
@@ -38,7 +37,7 @@ func makeFolder(context ExecutionContext) error {
 
 	target, _ := filepath.Abs(context.Args[0])
 
-	err := os.MkdirAll(target, 0775)
+	err := context.mkdirAll(target, 0775)
 
 	if err != nil {
 		return errors.New("Failed to create folder: " + err.Error())
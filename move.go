@@ -59,7 +59,7 @@ func move(context ExecutionContext) error {
 
 	for _, match := range matches {
 		if isValidPresetFolderName(target) {
-			os.MkdirAll(target, 0775)
+			context.mkdirAll(target, 0775)
 			if recursive {
 				if isDir(target) {
 					if isDir(filepath.Join(target, filepath.Base(source))) {
@@ -82,7 +82,7 @@ func move(context ExecutionContext) error {
 		}
 	}
 
-	statement, err := context.Database.Prepare("update pXcPresets set OriginalFileName = ?, FileFolder = ?, Name = ? where OriginalFileName = ?")
+	statement, err := context.prepare("update pXcPresets set OriginalFileName = ?, FileFolder = ?, Name = ? where OriginalFileName = ?")
 
 	if err != nil {
 		return errors.New("Failed preparing statement: " + err.Error())
@@ -96,13 +96,13 @@ func move(context ExecutionContext) error {
 			return errors.New("File not found: " + source + ".  " + err.Error())
 		}
 
-		err = os.MkdirAll(filepath.Dir(target), 0775)
+		err = context.mkdirAll(filepath.Dir(target), 0775)
 
 		if err != nil {
 			return errors.New("Failed to created directories :" + err.Error())
 		}
 
-		err = os.Rename(source, target)
+		err = context.rename(source, target)
 
 		if err != nil {
 			return errors.New("Failed to move preset with error: " + err.Error())
@@ -112,18 +112,18 @@ func move(context ExecutionContext) error {
 
 		if err != nil {
 			statement.Close()
-			rollbackMove(files)
+			rollbackMove(context, files)
 			return errors.New("Failed to update database records: " + err.Error())
 		}
 
 	}
 
 	if isDir(source) {
-		if isEmpty(source, true) {
-			os.RemoveAll(source)
+		if context.DryRun || isEmpty(source, true) {
+			context.removeAll(source)
 		} else {
 			statement.Close()
-			rollbackMove(files)
+			rollbackMove(context, files)
 			return errors.New("move failed while removing empty folders")
 		}
 	} else {
@@ -131,11 +131,11 @@ func move(context ExecutionContext) error {
 			cleanUp, _ := resolveToMatches(source, recursive, false)
 			for _, file := range cleanUp {
 				if file != target && strings.Index(file, target) != 0 {
-					if !isDir(file) || isEmpty(file, true) {
-						os.RemoveAll(file)
+					if context.DryRun || !isDir(file) || isEmpty(file, true) {
+						context.removeAll(file)
 					} else {
 						statement.Close()
-						rollbackMove(files)
+						rollbackMove(context, files)
 						return errors.New("move failed while removing empty folders")
 					}
 				}
@@ -149,11 +149,11 @@ func move(context ExecutionContext) error {
 
 }
 
-func rollbackMove(files map[string]string) {
+func rollbackMove(context ExecutionContext, files map[string]string) {
 	for source, target := range files {
 		_, err := os.Stat(target)
 		if err == nil {
-			os.Rename(target, source)
+			context.rename(target, source)
 		}
 	}
 }
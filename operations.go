@@ -0,0 +1,181 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// The methods below perform every change a Runner makes to presets, folders
+// and the preset database.  In dry-run mode they report the change instead,
+// otherwise the prior state is recorded in the journal.
+
+type Statement struct {
+	context ExecutionContext
+	query   string
+	stmt    *sql.Stmt
+}
+
+type dryRunResult struct{}
+
+func (dryRunResult) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (dryRunResult) RowsAffected() (int64, error) {
+	return 0, nil
+}
+
+func (context ExecutionContext) report(action string) {
+	fmt.Fprintln(out, "would "+action)
+}
+
+func (context ExecutionContext) writeFile(file string, data []byte, perm os.FileMode) error {
+	if context.DryRun {
+		context.report("write " + file)
+		return nil
+	}
+	context.Journal.recordFile("write", file)
+	return ioutil.WriteFile(file, data, perm)
+}
+
+func (context ExecutionContext) writePreset(file string, preset *Element) error {
+	data, err := marshalPreset(preset)
+	if err != nil {
+		return err
+	}
+	return context.writeFile(file, data, 0664)
+}
+
+func (context ExecutionContext) newGuid(file string) error {
+	if context.DryRun {
+		context.report("write new GUID to " + file)
+		return nil
+	}
+	context.Journal.recordFile("write", file)
+	return writeNewGuidToFile(file)
+}
+
+func (context ExecutionContext) mkdirAll(path string, perm os.FileMode) error {
+	if context.DryRun {
+		if !isDir(path) {
+			context.report("create folder " + path)
+		}
+		return nil
+	}
+	context.Journal.recordMkdir(path)
+	return os.MkdirAll(path, perm)
+}
+
+func (context ExecutionContext) rename(source string, target string) error {
+	if context.DryRun {
+		context.report("rename " + source + " to " + target)
+		return nil
+	}
+	context.Journal.recordRename(source, target)
+	return os.Rename(source, target)
+}
+
+func (context ExecutionContext) remove(path string) error {
+	if context.DryRun {
+		context.report("remove " + path)
+		return nil
+	}
+	context.Journal.recordFile("remove", path)
+	return os.Remove(path)
+}
+
+func (context ExecutionContext) removeAll(path string) error {
+	if context.DryRun {
+		context.report("remove " + path)
+		return nil
+	}
+	context.Journal.recordRemoveAll(path)
+	return os.RemoveAll(path)
+}
+
+func (context ExecutionContext) executor() Queryer {
+	if context.Tx != nil {
+		return context.Tx
+	}
+	return context.Database
+}
+
+func (context ExecutionContext) prepare(query string) (*Statement, error) {
+	if context.DryRun {
+		return &Statement{context: context, query: query}, nil
+	}
+	stmt, err := context.executor().Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return &Statement{context: context, query: query, stmt: stmt}, nil
+}
+
+func (context ExecutionContext) Exec(query string, args ...interface{}) (sql.Result, error) {
+	if context.DryRun {
+		context.report("execute " + formatSQL(query, args))
+		return dryRunResult{}, nil
+	}
+	return context.executor().Exec(query, args...)
+}
+
+func (context ExecutionContext) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return context.executor().Query(query, args...)
+}
+
+func (context ExecutionContext) QueryRow(query string, args ...interface{}) *sql.Row {
+	return context.executor().QueryRow(query, args...)
+}
+
+func (s *Statement) Exec(args ...interface{}) (sql.Result, error) {
+	if s.stmt == nil {
+		s.context.report("execute " + formatSQL(s.query, args))
+		return dryRunResult{}, nil
+	}
+	return s.stmt.Exec(args...)
+}
+
+func (s *Statement) Close() error {
+	if s.stmt == nil {
+		return nil
+	}
+	return s.stmt.Close()
+}
+
+func formatSQL(query string, args []interface{}) string {
+	var values []string
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case nil:
+			values = append(values, "NULL")
+		case string:
+			values = append(values, strconv.Quote(v))
+		default:
+			values = append(values, fmt.Sprint(v))
+		}
+	}
+	if len(values) == 0 {
+		return query
+	}
+	return query + " [" + strings.Join(values, ", ") + "]"
+}
@@ -0,0 +1,427 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const PackExtension = ".amptpack"
+const PackManifest = "manifest.json"
+const PackPresetsFolder = "presets"
+const PackVersion = 1
+
+// PackColumns are the pXcPresets columns copied as they are.  The columns
+// of the MetaFields with a lookup table are copied as the table description
+// so they can be matched to the tables of another profile.
+var PackColumns = []string{"UserId", "Product", "Favorite", "Description", "Downloads", "Keywords", "Song", "ChainA", "ChainB", "Rating", "MadeWith", "ChainType"}
+
+type PackManifestFile struct {
+	Version int          `json:"version"`
+	Created time.Time    `json:"created"`
+	Folders []string     `json:"folders,omitempty"`
+	Presets []PackPreset `json:"presets"`
+}
+
+type PackPreset struct {
+	Path    string                 `json:"path"`
+	Record  map[string]interface{} `json:"record,omitempty"`
+	Lookups map[string]string      `json:"lookups,omitempty"`
+}
+
+func pack(context ExecutionContext) error {
+
+	if len(context.Args) < 1 {
+		return errors.New("pack requires presets or folders to pack")
+	}
+
+	output := *context.Options["output"].(*string)
+
+	if output == "" {
+		source, _ := filepath.Abs(context.Args[0])
+		name := filepath.Base(source)
+		output = strings.TrimSuffix(name, filepath.Ext(name)) + PackExtension
+	}
+
+	manifest := PackManifestFile{Version: PackVersion, Created: time.Now()}
+	files := map[string][]byte{}
+
+	for _, arg := range context.Args {
+		if err := packPath(arg, &manifest, files); err != nil {
+			return err
+		}
+	}
+
+	if len(manifest.Presets) == 0 {
+		return errors.New("no presets found to pack")
+	}
+
+	var buffer bytes.Buffer
+
+	writer := zip.NewWriter(&buffer)
+
+	data, err := json.MarshalIndent(manifest, "", "\t")
+
+	if err != nil {
+		return err
+	}
+
+	if err = writePackEntry(writer, PackManifest, data); err != nil {
+		return err
+	}
+
+	for _, preset := range manifest.Presets {
+		if err = writePackEntry(writer, path.Join(PackPresetsFolder, preset.Path), files[preset.Path]); err != nil {
+			return err
+		}
+	}
+
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	if err = context.writeFile(output, buffer.Bytes(), 0664); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "packed "+strconv.Itoa(len(manifest.Presets))+" preset(s) to "+output)
+
+	return nil
+}
+
+func packPath(arg string, manifest *PackManifestFile, files map[string][]byte) error {
+
+	source, _ := filepath.Abs(arg)
+
+	if !isInPresetsFolder(source) {
+		return errors.New(arg + " is not in a presets folder")
+	}
+
+	matches, err := resolveToMatches(source, true, false)
+
+	if err != nil {
+		return err
+	}
+
+	database := openProfileDatabase(source)
+
+	if database != nil {
+		defer database.Close()
+	}
+
+	root := filepath.Dir(source)
+
+	if isDir(source) && !containsWildcards(source) {
+		manifest.Folders = append(manifest.Folders, filepath.Base(source))
+	}
+
+	for _, match := range sortMatches(matches) {
+
+		name, _ := filepath.Rel(root, match)
+		name = filepath.ToSlash(name)
+
+		if isDir(match) {
+			manifest.Folders = append(manifest.Folders, name)
+			continue
+		}
+
+		if !isValidPresetName(match) {
+			continue
+		}
+
+		if files[name] != nil {
+			return errors.New("more than one preset packed as " + name)
+		}
+
+		data, err := ioutil.ReadFile(match)
+
+		if err != nil {
+			return err
+		}
+
+		files[name] = data
+
+		preset := PackPreset{Path: name}
+
+		if database != nil {
+			if preset.Record, preset.Lookups, err = packRecord(database, match); err != nil {
+				return err
+			}
+		}
+
+		manifest.Presets = append(manifest.Presets, preset)
+	}
+
+	return nil
+}
+
+func writePackEntry(writer *zip.Writer, name string, data []byte) error {
+	entry, err := writer.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = entry.Write(data)
+	return err
+}
+
+func packRecord(database *sql.DB, file string) (map[string]interface{}, map[string]string, error) {
+
+	values := make([]interface{}, len(PackColumns))
+	pointers := make([]interface{}, len(PackColumns))
+
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	err := database.QueryRow("select "+strings.Join(PackColumns, ", ")+" from pXcPresets where OriginalFileName = ?", file).Scan(pointers...)
+
+	if err == sql.ErrNoRows {
+		return nil, nil, nil
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	record := map[string]interface{}{}
+
+	for i, column := range PackColumns {
+		if data, ok := values[i].([]byte); ok {
+			values[i] = string(data)
+		}
+		if values[i] != nil {
+			record[column] = values[i]
+		}
+	}
+
+	lookups := map[string]string{}
+
+	for _, field := range MetaFields {
+
+		if field.Table == "" {
+			continue
+		}
+
+		var description sql.NullString
+
+		err := database.QueryRow("select t.Description from pXcPresets p join "+field.Table+" t on t.Id = p."+field.Column+" where p.OriginalFileName = ?", file).Scan(&description)
+
+		if err != nil && err != sql.ErrNoRows {
+			return nil, nil, err
+		}
+
+		if description.Valid && description.String != "" {
+			lookups[field.Column] = description.String
+		}
+	}
+
+	return record, lookups, nil
+}
+
+func unpack(context ExecutionContext) error {
+
+	if len(context.Args) < 2 {
+		return errors.New("unpack requires a bundle and a profile")
+	}
+
+	bundle, _ := filepath.Abs(context.Args[0])
+	target, _ := filepath.Abs(context.Args[1])
+
+	profile, err := resolveToProfile(target)
+
+	if err != nil {
+		return errors.New("second arg must be the root of an Amplitube profile")
+	}
+
+	reader, err := zip.OpenReader(bundle)
+
+	if err != nil {
+		return errors.New("invalid bundle " + context.Args[0] + ": " + err.Error())
+	}
+
+	defer reader.Close()
+
+	entries := map[string]*zip.File{}
+
+	for _, entry := range reader.File {
+		entries[entry.Name] = entry
+	}
+
+	if entries[PackManifest] == nil {
+		return errors.New("invalid bundle " + context.Args[0] + ": missing " + PackManifest)
+	}
+
+	var manifest PackManifestFile
+
+	data, err := readPackEntry(entries[PackManifest])
+
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err = decoder.Decode(&manifest); err != nil {
+		return errors.New("invalid bundle manifest: " + err.Error())
+	}
+
+	if manifest.Version > PackVersion {
+		return errors.New("bundle version " + strconv.Itoa(manifest.Version) + " is not supported")
+	}
+
+	importPath := nextImportFolder(profile)
+
+	if err = context.mkdirAll(importPath, 0775); err != nil {
+		return err
+	}
+
+	for _, folder := range manifest.Folders {
+		folderPath, err := packTargetPath(importPath, folder)
+		if err != nil {
+			rollbackImport(context, importPath)
+			return err
+		}
+		if err = context.mkdirAll(folderPath, 0775); err != nil {
+			rollbackImport(context, importPath)
+			return err
+		}
+	}
+
+	for _, preset := range manifest.Presets {
+		if err = unpackPreset(context, entries, importPath, preset); err != nil {
+			rollbackImport(context, importPath)
+			return err
+		}
+	}
+
+	fmt.Fprintln(out, "unpacked "+strconv.Itoa(len(manifest.Presets))+" preset(s) to "+importPath)
+
+	return nil
+}
+
+func unpackPreset(context ExecutionContext, entries map[string]*zip.File, importPath string, preset PackPreset) error {
+
+	entry := entries[path.Join(PackPresetsFolder, preset.Path)]
+
+	if entry == nil || !isValidPresetName(preset.Path) {
+		return errors.New("invalid bundle: missing preset " + preset.Path)
+	}
+
+	target, err := packTargetPath(importPath, preset.Path)
+
+	if err != nil {
+		return err
+	}
+
+	data, err := readPackEntry(entry)
+
+	if err != nil {
+		return err
+	}
+
+	if err = context.mkdirAll(filepath.Dir(target), 0775); err != nil {
+		return errors.New("Failed to created directories :" + err.Error())
+	}
+
+	if err = context.writeFile(target, data, 0644); err != nil {
+		return errors.New("Could not write file: " + err.Error())
+	}
+
+	if err = context.newGuid(target); err != nil {
+		return errors.New("Failed to generate new GUID for unpacked file: " + err.Error())
+	}
+
+	if context.Database == nil {
+		return nil
+	}
+
+	if preset.Record == nil {
+		fmt.Fprintln(out, preset.Path+" unpacked without database record")
+		return nil
+	}
+
+	columns := []string{"OriginalFileName", "FileFolder", "Name"}
+	values := []interface{}{target, filepath.Dir(target), makePresetPath(filepath.Base(target))}
+
+	for _, column := range PackColumns {
+		value, ok := preset.Record[column]
+		if !ok {
+			continue
+		}
+		// numbers are kept as integers where they can be so that integer
+		// columns are not written as reals
+		if number, isNumber := value.(json.Number); isNumber {
+			if value, err = number.Int64(); err != nil {
+				value, err = number.Float64()
+			}
+		}
+		columns = append(columns, column)
+		values = append(values, value)
+	}
+
+	for _, field := range MetaFields {
+		description, ok := preset.Lookups[field.Column]
+		if field.Table == "" || !ok {
+			continue
+		}
+		value, err := metaColumnValue(context, field, description)
+		if err != nil {
+			return err
+		}
+		columns = append(columns, field.Column)
+		values = append(values, value)
+	}
+
+	_, err = context.Exec("insert into pXcPresets ("+strings.Join(columns, ", ")+") values (?"+strings.Repeat(", ?", len(columns)-1)+")", values...)
+
+	if err != nil {
+		return errors.New("Unpack failed.  Failed to update database: " + err.Error())
+	}
+
+	return nil
+}
+
+// packTargetPath joins a bundle path to the import folder, refusing paths
+// that would leave it.
+func packTargetPath(importPath string, name string) (string, error) {
+	target := filepath.Join(importPath, filepath.FromSlash(name))
+	if !isSubDir(importPath, target) || target == importPath {
+		return "", errors.New("invalid bundle path " + name)
+	}
+	return target, nil
+}
+
+func readPackEntry(entry *zip.File) ([]byte, error) {
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return ioutil.ReadAll(reader)
+}
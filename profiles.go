@@ -54,3 +54,13 @@ func resolveToProfile(startPath string) (string, error) {
 
 	return currPath, nil
 }
+
+func findProfile(args []string) string {
+	for _, arg := range args {
+		path, _ := filepath.Abs(arg)
+		if profile, err := resolveToProfile(path); err == nil {
+			return profile
+		}
+	}
+	return ""
+}
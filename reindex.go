@@ -33,9 +33,7 @@ func reindex(context ExecutionContext) error {
 		return errors.New("arg must be the root of an Amplitube profile")
 	}
 
-	database := context.Database
-
-	statement, err := database.Prepare("update pXcPresets set OriginalFileName = ? || substr(OriginalFileName, instr(OriginalFileName, ?)), FileFolder = ? || substr(FileFolder, instr(FileFolder, ?))")
+	statement, err := context.prepare("update pXcPresets set OriginalFileName = ? || substr(OriginalFileName, instr(OriginalFileName, ?)), FileFolder = ? || substr(FileFolder, instr(FileFolder, ?))")
 
 	if err != nil {
 		return err
@@ -62,7 +62,7 @@ func remove(context ExecutionContext) error {
 		dirs = append(dirs, source)
 	}
 
-	statement, err := context.Database.Prepare("delete from pXcPresets where OriginalFileName = ?")
+	statement, err := context.prepare("delete from pXcPresets where OriginalFileName = ?")
 
 	if err != nil {
 		return errors.New("Failed preparing statement: " + err.Error())
@@ -73,32 +73,36 @@ func remove(context ExecutionContext) error {
 		info, err := os.Stat(target)
 
 		if err != nil {
-			rollbackRemove(files)
+			rollbackRemove(context, files)
 			return errors.New("Unable to stat " + target + ".  " + err.Error())
 		}
 
 		if !info.IsDir() {
 
-			rollback := filepath.Join(filepath.Dir(target), "_"+filepath.Base(target))
+			if !context.DryRun {
 
-			data, err := ioutil.ReadFile(target)
+				rollback := filepath.Join(filepath.Dir(target), "_"+filepath.Base(target))
 
-			if err != nil {
-				rollbackRemove(files)
-				return errors.New("Failed to backup file before delete: " + err.Error())
-			}
+				data, err := ioutil.ReadFile(target)
 
-			err = ioutil.WriteFile(rollback, data, 0644)
+				if err != nil {
+					rollbackRemove(context, files)
+					return errors.New("Failed to backup file before delete: " + err.Error())
+				}
+
+				err = ioutil.WriteFile(rollback, data, 0644)
+
+				if err != nil {
+					rollbackRemove(context, files)
+					return errors.New("Failed to backup file before delete: " + err.Error())
+				}
 
-			if err != nil {
-				rollbackRemove(files)
-				return errors.New("Failed to backup file before delete: " + err.Error())
 			}
 
-			err = os.Remove(target)
+			err = context.remove(target)
 
 			if err != nil {
-				rollbackRemove(files)
+				rollbackRemove(context, files)
 				return errors.New("Failed to remove file: " + err.Error())
 			}
 
@@ -106,7 +110,7 @@ func remove(context ExecutionContext) error {
 
 			if err != nil {
 				statement.Close()
-				rollbackRemove(files)
+				rollbackRemove(context, files)
 				return errors.New("Failed to remove file: " + err.Error())
 			}
 		}
@@ -118,14 +122,17 @@ func remove(context ExecutionContext) error {
 	}
 
 	for _, target := range dirs {
-		os.RemoveAll(target)
+		context.removeAll(target)
 	}
 
 	return nil
 
 }
 
-func rollbackRemove(files []string) {
+func rollbackRemove(context ExecutionContext, files []string) {
+	if context.DryRun {
+		return
+	}
 	for _, target := range files {
 		rollback := filepath.Join(filepath.Dir(target), "_"+filepath.Base(target))
 		data, _ := ioutil.ReadFile(rollback)
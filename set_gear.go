@@ -1,265 +1,232 @@
 package main
 
 import (
-	"encoding/xml"
 	"errors"
-	"io/ioutil"
+	"math"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
+type AttrAssignment struct {
+	Path     []string
+	Operator string
+	Value    string
+	Min      float64
+	Max      float64
+}
+
 func setGear(context ExecutionContext) error {
 
-	var err error
+	if len(context.Args) < 2 {
+		return errors.New("set gear requires a preset and an attribute assignment")
+	}
 
 	recursive := *context.Options["recursive"].(*bool)
 
-	matches, _ := resolveToMatches(context.Args[0], recursive, true)
+	matches, err := resolveToMatches(context.Args[0], recursive, true)
+
+	if err != nil {
+		return err
+	}
+
+	assignment, err := parseAttrAssignment(context.Args[1])
 
-	nameValuePair := strings.Split(context.Args[1], "=")
+	if err != nil {
+		return err
+	}
+
+	if assignment.Min, err = parseBound(*context.Options["min"].(*string), math.Inf(-1)); err != nil {
+		return errors.New("invalid minimum " + *context.Options["min"].(*string))
+	}
 
-	path := strings.Split(nameValuePair[0], ".")
-	newValue := nameValuePair[1]
+	if assignment.Max, err = parseBound(*context.Options["max"].(*string), math.Inf(1)); err != nil {
+		return errors.New("invalid maximum " + *context.Options["max"].(*string))
+	}
+
+	path := assignment.Path
 
+	if len(path) == 2 && path[0] == "MetaInfo" && assignment.isPlain() && context.Database != nil {
+		if field, ok := lookupMetaField(path[1]); ok && field.Attr != "" {
+			return setMeta(context, matches, []MetaAssignment{{Field: field, Value: assignment.Value}})
+		}
+	}
+
+	presets := map[string]*Element{}
+
+	// every preset is checked before any are written so an unsupported path
+	// does not leave a folder partly changed
 	for _, match := range matches {
 
 		source, _ := filepath.Abs(match)
 
-		sourceFile, _ := ioutil.ReadFile(source)
-
-		var preset PresetXMLV5Raw
-		_ = xml.Unmarshal(sourceFile, &preset)
-
-		attrName := path[len(path)-1]
-
-		switch path[len(path)-2] {
-		case "Preset":
-			updateAttr(&preset.Attrs, attrName, newValue)
-		case "Chain":
-			updateAttr(&preset.Chain.Attrs, attrName, newValue)
-		case "Input":
-			updateAttr(&preset.Input.Attrs, attrName, newValue)
-		case "Tuner":
-			if path[len(path)-3] == "Preset" {
-				updateAttr(&preset.Tuner.Attrs, attrName, newValue)
-			} else {
-				updateAttr(&preset.Tuner.Tuner.Attrs, attrName, newValue)
-			}
-		case "Slot0":
-			switch path[len(path)-3] {
-			case "StompA1":
-				err = updateAttr(&preset.StompA1.Slot0.Attrs, attrName, newValue)
-			case "StompA2":
-				err = updateAttr(&preset.StompA2.Slot0.Attrs, attrName, newValue)
-			case "StompStereo":
-				err = updateAttr(&preset.StompStereo.Slot0.Attrs, attrName, newValue)
-			case "StompB1":
-				err = updateAttr(&preset.StompB1.Slot0.Attrs, attrName, newValue)
-			case "StompB2":
-				err = updateAttr(&preset.StompB2.Slot0.Attrs, attrName, newValue)
-			case "StompB3":
-				err = updateAttr(&preset.StompB3.Slot0.Attrs, attrName, newValue)
-			case "LoopFxA":
-				err = updateAttr(&preset.LoopFxA.Slot0.Attrs, attrName, newValue)
-			case "LoopFxB":
-				err = updateAttr(&preset.LoopFxB.Slot0.Attrs, attrName, newValue)
-			case "LoopFxC":
-				err = updateAttr(&preset.LoopFxC.Slot0.Attrs, attrName, newValue)
-			case "RackA":
-				err = updateAttr(&preset.RackA.Slot0.Attrs, attrName, newValue)
-			case "RackB":
-				err = updateAttr(&preset.RackB.Slot0.Attrs, attrName, newValue)
-			case "RackC":
-				err = updateAttr(&preset.RackC.Slot0.Attrs, attrName, newValue)
-			case "RackDI":
-				err = updateAttr(&preset.RackDI.Slot0.Attrs, attrName, newValue)
-			case "RackMaster":
-				err = updateAttr(&preset.RackMaster.Slot0.Attrs, attrName, newValue)
-			default:
-				err = errors.New("invalid or unsupported path " + strings.Join(path, "."))
-			}
-		case "Slot1":
-			switch path[len(path)-3] {
-			case "StompA1":
-				err = updateAttr(&preset.StompA1.Slot1.Attrs, attrName, newValue)
-			case "StompA2":
-				err = updateAttr(&preset.StompA2.Slot1.Attrs, attrName, newValue)
-			case "StompStereo":
-				err = updateAttr(&preset.StompStereo.Slot1.Attrs, attrName, newValue)
-			case "StompB1":
-				err = updateAttr(&preset.StompB1.Slot1.Attrs, attrName, newValue)
-			case "StompB2":
-				err = updateAttr(&preset.StompB2.Slot1.Attrs, attrName, newValue)
-			case "StompB3":
-				err = updateAttr(&preset.StompB3.Slot1.Attrs, attrName, newValue)
-			case "LoopFxA":
-				err = updateAttr(&preset.LoopFxA.Slot1.Attrs, attrName, newValue)
-			case "LoopFxB":
-				err = updateAttr(&preset.LoopFxB.Slot1.Attrs, attrName, newValue)
-			case "LoopFxC":
-				err = updateAttr(&preset.LoopFxC.Slot1.Attrs, attrName, newValue)
-			case "RackA":
-				err = updateAttr(&preset.RackA.Slot1.Attrs, attrName, newValue)
-			case "RackB":
-				err = updateAttr(&preset.RackB.Slot1.Attrs, attrName, newValue)
-			case "RackC":
-				err = updateAttr(&preset.RackC.Slot1.Attrs, attrName, newValue)
-			case "RackDI":
-				err = updateAttr(&preset.RackDI.Slot1.Attrs, attrName, newValue)
-			case "RackMaster":
-				err = updateAttr(&preset.RackMaster.Slot1.Attrs, attrName, newValue)
-			default:
-				err = errors.New("invalid or unsupported path " + strings.Join(path, "."))
-			}
-		case "Slot2":
-			switch path[len(path)-3] {
-			case "StompA1":
-				err = updateAttr(&preset.StompA1.Slot2.Attrs, attrName, newValue)
-			case "StompA2":
-				err = updateAttr(&preset.StompA2.Slot2.Attrs, attrName, newValue)
-			case "StompStereo":
-				err = updateAttr(&preset.StompStereo.Slot2.Attrs, attrName, newValue)
-			case "StompB1":
-				err = updateAttr(&preset.StompB1.Slot2.Attrs, attrName, newValue)
-			case "StompB2":
-				err = updateAttr(&preset.StompB2.Slot2.Attrs, attrName, newValue)
-			case "StompB3":
-				err = updateAttr(&preset.StompB3.Slot2.Attrs, attrName, newValue)
-			case "LoopFxA":
-				err = updateAttr(&preset.LoopFxA.Slot2.Attrs, attrName, newValue)
-			case "LoopFxB":
-				err = updateAttr(&preset.LoopFxB.Slot2.Attrs, attrName, newValue)
-			case "LoopFxC":
-				err = updateAttr(&preset.LoopFxC.Slot2.Attrs, attrName, newValue)
-			case "RackMaster":
-				err = updateAttr(&preset.RackMaster.Slot2.Attrs, attrName, newValue)
-			default:
-				err = errors.New("invalid or unsupported path " + strings.Join(path, "."))
-			}
-		case "Slot3":
-			switch path[len(path)-3] {
-			case "StompA1":
-				err = updateAttr(&preset.StompA1.Slot3.Attrs, attrName, newValue)
-			case "StompA2":
-				err = updateAttr(&preset.StompA2.Slot3.Attrs, attrName, newValue)
-			case "StompB1":
-				err = updateAttr(&preset.StompB1.Slot3.Attrs, attrName, newValue)
-			case "StompB2":
-				err = updateAttr(&preset.StompB2.Slot3.Attrs, attrName, newValue)
-			case "StompB3":
-				err = updateAttr(&preset.StompB3.Slot3.Attrs, attrName, newValue)
-			case "LoopFxA":
-				err = updateAttr(&preset.LoopFxA.Slot3.Attrs, attrName, newValue)
-			case "LoopFxB":
-				err = updateAttr(&preset.LoopFxB.Slot3.Attrs, attrName, newValue)
-			case "LoopFxC":
-				err = updateAttr(&preset.LoopFxC.Slot3.Attrs, attrName, newValue)
-			case "RackMaster":
-				err = updateAttr(&preset.RackMaster.Slot3.Attrs, attrName, newValue)
-			default:
-				err = errors.New("invalid or unsupported path " + strings.Join(path, "."))
-			}
-		case "Slot4":
-			switch path[len(path)-3] {
-			case "StompA1":
-				err = updateAttr(&preset.StompA1.Slot4.Attrs, attrName, newValue)
-			case "StompA2":
-				err = updateAttr(&preset.StompA2.Slot4.Attrs, attrName, newValue)
-			case "StompB1":
-				err = updateAttr(&preset.StompB1.Slot4.Attrs, attrName, newValue)
-			case "StompB2":
-				err = updateAttr(&preset.StompB2.Slot4.Attrs, attrName, newValue)
-			case "StompB3":
-				err = updateAttr(&preset.StompB3.Slot4.Attrs, attrName, newValue)
-			case "RackMaster":
-				err = updateAttr(&preset.RackMaster.Slot4.Attrs, attrName, newValue)
-			default:
-				err = errors.New("invalid or unsupported path " + strings.Join(path, "."))
-			}
-		case "Slot5":
-			switch path[len(path)-3] {
-			case "StompA1":
-				err = updateAttr(&preset.StompA1.Slot5.Attrs, attrName, newValue)
-			case "StompA2":
-				err = updateAttr(&preset.StompA2.Slot5.Attrs, attrName, newValue)
-			case "StompB1":
-				err = updateAttr(&preset.StompB1.Slot5.Attrs, attrName, newValue)
-			case "StompB2":
-				err = updateAttr(&preset.StompB2.Slot5.Attrs, attrName, newValue)
-			case "StompB3":
-				err = updateAttr(&preset.StompB3.Slot5.Attrs, attrName, newValue)
-			case "RackMaster":
-				err = updateAttr(&preset.RackMaster.Slot5.Attrs, attrName, newValue)
-			default:
-				err = errors.New("invalid or unsupported path " + strings.Join(path, "."))
-			}
-		case "Amp":
-			switch path[len(path)-3] {
-			case "AmpA":
-				err = updateAttr(&preset.AmpA.Amp.Attrs, attrName, newValue)
-			case "AmpB":
-				err = updateAttr(&preset.AmpB.Amp.Attrs, attrName, newValue)
-			case "AmpC":
-				err = updateAttr(&preset.AmpC.Amp.Attrs, attrName, newValue)
-			default:
-				err = errors.New("invalid or unsupported path " + strings.Join(path, "."))
-			}
-		case "AmpA":
-			err = updateAttr(&preset.AmpA.Attrs, attrName, newValue)
-		case "AmpB":
-			err = updateAttr(&preset.AmpB.Attrs, attrName, newValue)
-		case "AmpC":
-			err = updateAttr(&preset.AmpC.Attrs, attrName, newValue)
-		case "Cab":
-			switch path[len(path)-3] {
-			case "CabA":
-				err = updateAttr(&preset.CabA.Cab.Attrs, attrName, newValue)
-			case "CabB":
-				err = updateAttr(&preset.CabB.Cab.Attrs, attrName, newValue)
-			case "CabC":
-				err = updateAttr(&preset.CabC.Cab.Attrs, attrName, newValue)
-			default:
-				err = errors.New("invalid or unsupported path " + strings.Join(path, "."))
-			}
-		case "CabA":
-			err = updateAttr(&preset.CabA.Attrs, attrName, newValue)
-		case "CabB":
-			err = updateAttr(&preset.CabB.Attrs, attrName, newValue)
-		case "CabC":
-			err = updateAttr(&preset.CabC.Attrs, attrName, newValue)
-		case "Studio":
-			err = updateAttr(&preset.Studio.Attrs, attrName, newValue)
-		case "Output":
-			err = updateAttr(&preset.Output.Attrs, attrName, newValue)
-		case "MidiAssignments":
-			err = updateAttr(&preset.MidiAssignments.Attrs, attrName, newValue)
-		case "MetaInfo":
-			err = updateAttr(&preset.MetaInfo.Attrs, attrName, newValue)
-		default:
-			err = errors.New("invalid or unsupported path " + strings.Join(path, "."))
+		preset, err := readPresetFile(source)
+
+		if err != nil {
+			return err
+		}
+
+		count, err := assignment.apply(preset)
+
+		if err != nil {
+			return errors.New(match + ": " + err.Error())
 		}
 
-		if err == nil {
-			newData, _ := xml.MarshalIndent(preset, "", "    ")
-			ioutil.WriteFile(source, selfClose(append([]byte(XMLPrefix), append(newData[:], '\n')...)), 0664)
+		if count > 0 {
+			presets[source] = preset
 		}
+	}
 
+	if len(presets) == 0 {
+		return errors.New("no attributes match " + strings.Join(path, "."))
 	}
 
-	return err
+	for _, match := range matches {
+
+		source, _ := filepath.Abs(match)
+
+		if presets[source] == nil {
+			continue
+		}
+
+		if err = context.writePreset(source, presets[source]); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
 }
 
-func updateAttr(attrs *[]xml.Attr, name string, value string) error {
-	updated := false
-	for i, thisAttr := range *attrs {
-		if thisAttr.Name.Local == name {
-			(*attrs)[i].Value = value
-			updated = true
+func parseAttrAssignment(arg string) (AttrAssignment, error) {
+
+	index := strings.Index(arg, "=")
+
+	if index < 0 {
+		return AttrAssignment{}, errors.New("attribute assignment must be in the form Path.Name=value")
+	}
+
+	assignment := AttrAssignment{Operator: "=", Value: arg[index+1:]}
+	name := arg[:index]
+
+	if index > 0 && strings.ContainsAny(arg[index-1:index], "+-*") {
+		assignment.Operator = arg[index-1 : index+1]
+		name = arg[:index-1]
+	}
+
+	path := strings.Split(name, ".")
+
+	if path[0] == "Preset" {
+		path = path[1:]
+	}
+
+	if len(path) == 0 || path[len(path)-1] == "" {
+		return AttrAssignment{}, errors.New("invalid or unsupported path " + name)
+	}
+
+	assignment.Path = path
+
+	return assignment, nil
+}
+
+func parseBound(value string, unbounded float64) (float64, error) {
+	if value == "" {
+		return unbounded, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func (a AttrAssignment) isPlain() bool {
+	return a.Operator == "=" && !hasWildcard(a.Path) && math.IsInf(a.Min, -1) && math.IsInf(a.Max, 1)
+}
+
+// apply sets every attribute matching the assignment path and returns the
+// number set.  Paths with wildcards may match nothing in a preset.
+func (a AttrAssignment) apply(preset *Element) (int, error) {
+
+	name := a.Path[len(a.Path)-1]
+	elementPath := append([]string{"Preset"}, a.Path[:len(a.Path)-1]...)
+
+	if !hasWildcard(a.Path) {
+		element, err := preset.find(a.Path[:len(a.Path)-1])
+		if err != nil {
+			return 0, errors.New("invalid or unsupported path " + strings.Join(append(elementPath, name), "."))
+		}
+		if !element.hasAttr(name) {
+			return 0, errors.New("attribute " + name + " not found in " + strings.Join(elementPath, "."))
 		}
 	}
-	if !updated {
-		return errors.New("attribute not found: " + name)
-	} else {
-		return nil
+
+	count := 0
+
+	for _, element := range preset.findAll(a.Path[:len(a.Path)-1]) {
+		for _, attr := range element.matchAttrs(name) {
+			value, err := a.evaluate(attr.Value)
+			if err != nil {
+				return 0, errors.New(attr.Name.Local + ": " + err.Error())
+			}
+			attr.Value = value
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (a AttrAssignment) evaluate(current string) (string, error) {
+
+	if a.Operator == "=" {
+		value, err := strconv.ParseFloat(a.Value, 64)
+		if err != nil || (value >= a.Min && value <= a.Max) {
+			return a.Value, nil
+		}
+		return formatNumber(math.Min(math.Max(value, a.Min), a.Max)), nil
+	}
+
+	value, err := strconv.ParseFloat(current, 64)
+
+	if err != nil {
+		return "", errors.New("value " + current + " is not a number")
+	}
+
+	operand, err := strconv.ParseFloat(strings.TrimSuffix(a.Value, "%"), 64)
+
+	if err != nil {
+		return "", errors.New("invalid number " + a.Value)
+	}
+
+	if strings.HasSuffix(a.Value, "%") {
+		operand = operand / 100
+		if a.Operator != "*=" {
+			operand *= value
+		}
+	}
+
+	switch a.Operator {
+	case "+=":
+		value += operand
+	case "-=":
+		value -= operand
+	case "*=":
+		value *= operand
+	}
+
+	return formatNumber(math.Min(math.Max(value, a.Min), a.Max)), nil
+}
+
+func formatNumber(value float64) string {
+	formatted := strconv.FormatFloat(value, 'g', 6, 64)
+	if strings.Contains(formatted, "e") {
+		formatted = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return formatted
+}
+
+func hasWildcard(path []string) bool {
+	for _, name := range path {
+		if strings.ContainsAny(name, "*?[") {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,427 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const MaxSuggestions = 5
+
+func setModel(context ExecutionContext) error {
+
+	if len(context.Args) < 3 {
+		return errors.New("set gear model requires a preset, a slot or model path and a model name")
+	}
+
+	recursive := *context.Options["recursive"].(*bool)
+	force := *context.Options["force"].(*bool)
+
+	matches, err := resolveToMatches(context.Args[0], recursive, true)
+
+	if err != nil {
+		return err
+	}
+
+	path := strings.Split(context.Args[1], ".")
+
+	if path[0] == "Preset" {
+		path = path[1:]
+	}
+
+	if len(path) != 2 {
+		return errors.New("invalid or unsupported path " + context.Args[1])
+	}
+
+	presets := map[string]*Element{}
+	defaults := newModelDefaults(findProfile(context.Args))
+
+	for _, match := range matches {
+
+		source, _ := filepath.Abs(match)
+
+		preset, err := readPresetFile(source)
+
+		if err != nil {
+			return err
+		}
+
+		schema, err := schemaForFormat(preset.attr("Format"))
+
+		if err != nil {
+			return err
+		}
+
+		warnings, err := setPresetModel(preset, schema, path, context.Args[2], defaults, force)
+
+		if err != nil {
+			return err
+		}
+
+		for _, warning := range warnings {
+			fmt.Fprintln(out, filepath.Base(match)+": "+warning)
+		}
+
+		presets[source] = preset
+	}
+
+	for _, match := range matches {
+
+		source, _ := filepath.Abs(match)
+
+		if err = context.writePreset(source, presets[source]); err != nil {
+			return err
+		}
+
+	}
+
+	return nil
+}
+
+func setPresetModel(preset *Element, schema PresetSchema, path []string, model string, defaults *ModelDefaults, force bool) ([]string, error) {
+
+	block, ok := schema.block(path[0])
+
+	if !ok {
+		return nil, errors.New("unknown block " + path[0])
+	}
+
+	element := preset.child(block.Name)
+
+	if element == nil {
+		return nil, errors.New(block.Name + " not found in preset")
+	}
+
+	var warnings []string
+
+	switch {
+	case block.Kind == FxBlock && isSlotName(path[1]):
+		guid, err := lookupModel(FX, "fx", model)
+		if err != nil {
+			return nil, err
+		}
+		location, err := parseGearLocation(schema, strings.Join(path, "."))
+		if err != nil {
+			return nil, err
+		}
+		slot := defaults.parameters(schema, FxBlock, guid)
+		if len(slot.Attrs) == 0 {
+			if !force {
+				return nil, noDefaultsError("fx", FX[guid])
+			}
+			warnings = append(warnings, FX[guid]+" set without parameters")
+		}
+		slots := fxSlots(element, block)
+		slot.XMLName.Local = path[1]
+		slots[location.Slot] = FxSlot{GUID: guid, Slot: slot}
+		setFxSlots(element, block, slots)
+	case block.Kind == AmpBlock && path[1] == "Model":
+		guid, err := lookupModel(Amps, "amp", model)
+		if err != nil {
+			return nil, err
+		}
+		if guid == element.attr("Model") {
+			return nil, nil
+		}
+		if schema.Format == SchemaV5.Format {
+			amp := defaults.parameters(schema, AmpBlock, guid)
+			if len(amp.Attrs) == 0 {
+				if !force {
+					return nil, noDefaultsError("amp", Amps[guid])
+				}
+				warnings = append(warnings, Amps[guid]+" set without parameters")
+			}
+			amp.XMLName.Local = "Amp"
+			element.replaceChild("Amp", amp)
+		}
+		element.putAttr("Model", guid)
+	case block.Kind == CabBlock && path[1] == "CabModel":
+		guid, err := lookupModel(Cabs, "cab", model)
+		if err != nil {
+			return nil, err
+		}
+		speakers := defaults.parameters(schema, CabBlock, guid)
+		if len(speakers.Attrs) == 0 {
+			if !force {
+				return nil, noDefaultsError("cab", Cabs[guid])
+			}
+			warnings = append(warnings, Cabs[guid]+" set with the speakers of the previous cab")
+		}
+		element.putAttr("CabModel", guid)
+		fitCabSpeakers(element, SpeakerCount[guid])
+		for _, a := range speakers.Attrs {
+			element.putAttr(a.Name.Local, a.Value)
+		}
+	default:
+		return nil, errors.New("invalid or unsupported path " + strings.Join(path, "."))
+	}
+
+	return warnings, nil
+}
+
+func noDefaultsError(kind string, name string) error {
+	return errors.New("no parameters for " + kind + " model " + name + " in the gear catalog or the presets of the profile; use -force to set it anyway")
+}
+
+// fitCabSpeakers removes the speakers a cab does not have and points the
+// mics of the cab at its first speaker when their speaker is gone.
+func fitCabSpeakers(element *Element, count int) {
+
+	if count == 0 {
+		return
+	}
+
+	for i := count; i < 4; i++ {
+		element.removeAttr("SpeakerModel" + strconv.Itoa(i))
+	}
+
+	params := element.child("Cab")
+
+	if params == nil {
+		return
+	}
+
+	for _, name := range []string{"Mic0Speaker", "Mic1Speaker"} {
+		if speaker, err := strconv.Atoi(params.attr(name)); err == nil && speaker >= count {
+			params.putAttr(name, "0")
+		}
+	}
+}
+
+// ModelDefaults finds the parameters a model is set with: its defaults in the
+// gear catalog, or else its parameters in the first preset of the profile
+// using it.  Presets are only searched once for each model.
+type ModelDefaults struct {
+	Profile string
+	found   map[string]*Element
+}
+
+func newModelDefaults(profile string) *ModelDefaults {
+	return &ModelDefaults{Profile: profile, found: map[string]*Element{}}
+}
+
+func (d *ModelDefaults) parameters(schema PresetSchema, kind BlockKind, guid string) *Element {
+
+	if element := gearDefaults(guid); len(element.Attrs) > 0 {
+		return element
+	}
+
+	key := schema.Format + ":" + guid
+
+	if _, ok := d.found[key]; !ok {
+		d.found[key] = libraryParameters(d.Profile, schema, kind, guid)
+	}
+
+	if d.found[key] == nil {
+		return &Element{}
+	}
+
+	return d.found[key].clone()
+}
+
+func gearDefaults(guid string) *Element {
+	element := &Element{}
+	for _, parameter := range GearParameters[guid] {
+		if parameter.Default != "" {
+			element.putAttr(parameter.Name, parameter.Default)
+		}
+	}
+	return element
+}
+
+func libraryParameters(profile string, schema PresetSchema, kind BlockKind, guid string) *Element {
+
+	if profile == "" {
+		return nil
+	}
+
+	var found *Element
+
+	filepath.Walk(filepath.Join(profile, PresetsFolder), func(file string, info os.FileInfo, err error) error {
+		if found != nil || err != nil || info.IsDir() || !isValidPresetName(file) {
+			return nil
+		}
+		preset, err := readPresetFile(file)
+		if err != nil || preset.attr("Format") != schema.Format {
+			return nil
+		}
+		found = presetModelParameters(preset, schema, kind, guid)
+		return nil
+	})
+
+	return found
+}
+
+// presetModelParameters returns the parameters of the first block or slot of
+// a preset using a model: the slot of an effect, the Amp element of an amp
+// or the speakers of a cab.
+func presetModelParameters(preset *Element, schema PresetSchema, kind BlockKind, guid string) *Element {
+
+	for _, block := range schema.blocksOfKind(kind) {
+
+		element := preset.child(block.Name)
+
+		if element == nil {
+			continue
+		}
+
+		switch kind {
+		case FxBlock:
+			for _, slot := range fxSlots(element, block) {
+				if slot.GUID == guid && len(slot.Slot.Attrs) > 0 {
+					return slot.Slot.clone()
+				}
+			}
+		case AmpBlock:
+			if params := element.child("Amp"); element.attr("Model") == guid && params != nil && len(params.Attrs) > 0 {
+				return params.clone()
+			}
+		case CabBlock:
+			if element.attr("CabModel") == guid {
+				speakers := &Element{}
+				for _, a := range element.matchAttrs("SpeakerModel*") {
+					speakers.putAttr(a.Name.Local, a.Value)
+				}
+				if len(speakers.Attrs) > 0 {
+					return speakers
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// lookupModel finds the GUID of a model by name ignoring case, spaces and
+// punctuation, or by a part of the name that matches only one model.
+// Otherwise the closest names are suggested.
+func lookupModel(models map[string]string, kind string, name string) (string, error) {
+
+	if _, ok := models[name]; ok {
+		return name, nil
+	}
+
+	wanted := normalizeModelName(name)
+
+	var guids []string
+	for guid := range models {
+		if !isEmptyFx(guid) {
+			guids = append(guids, guid)
+		}
+	}
+	sort.Strings(guids)
+
+	var partial []string
+	for _, guid := range guids {
+		normalized := normalizeModelName(models[guid])
+		if normalized == wanted {
+			return guid, nil
+		}
+		if wanted != "" && strings.Contains(normalized, wanted) && !containsModel(partial, models, models[guid]) {
+			partial = append(partial, guid)
+		}
+	}
+
+	if len(partial) == 1 {
+		return partial[0], nil
+	}
+
+	if len(partial) > 1 {
+		return "", errors.New("ambiguous " + kind + " model " + name + "; did you mean " + suggestModels(models, partial, wanted))
+	}
+
+	return "", errors.New("unknown " + kind + " model " + name + "; did you mean " + suggestModels(models, guids, wanted))
+}
+
+func containsModel(guids []string, models map[string]string, name string) bool {
+	for _, guid := range guids {
+		if models[guid] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func suggestModels(models map[string]string, guids []string, wanted string) string {
+
+	var names []string
+	distances := map[string]int{}
+
+	for _, guid := range guids {
+		name := models[guid]
+		if _, ok := distances[name]; !ok {
+			distances[name] = editDistance(normalizeModelName(name), wanted)
+			names = append(names, name)
+		}
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		if distances[names[i]] != distances[names[j]] {
+			return distances[names[i]] < distances[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	if len(names) > MaxSuggestions {
+		names = names[:MaxSuggestions]
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func normalizeModelName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(current[j-1]+1, previous[j]+1), previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
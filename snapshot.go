@@ -0,0 +1,511 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const SnapshotFolder = "snapshots"
+const SnapshotObjectFolder = "objects"
+
+// A Snapshot records the Presets tree and the database of a profile.  The
+// contents of each file are stored once in the object folder, named by their
+// hash, so presets unchanged between snapshots are not stored twice.
+type Snapshot struct {
+	Id       int            `json:"-"`
+	Time     time.Time      `json:"time"`
+	Message  string         `json:"message,omitempty"`
+	Database string         `json:"database"`
+	Folders  []string       `json:"folders,omitempty"`
+	Files    []SnapshotFile `json:"files"`
+}
+
+type SnapshotFile struct {
+	Path string `json:"path"`
+	Hash string `json:"hash"`
+}
+
+func snapshot(context ExecutionContext) error {
+
+	if len(context.Args) < 2 {
+		return errors.New("snapshot requires create, list, restore or delete and a profile")
+	}
+
+	source, _ := filepath.Abs(context.Args[1])
+	profile, err := resolveToProfile(source)
+
+	if err != nil {
+		return errors.New("arg must be the root of an Amplitube profile")
+	}
+
+	switch context.Args[0] {
+	case "create":
+		return createSnapshot(context, profile, *context.Options["message"].(*string))
+	case "list":
+		return listSnapshots(profile)
+	case "restore", "delete":
+		if len(context.Args) < 3 {
+			return errors.New("snapshot " + context.Args[0] + " requires a profile and a snapshot id")
+		}
+		id, err := strconv.Atoi(context.Args[2])
+		if err != nil {
+			return errors.New("invalid snapshot id " + context.Args[2])
+		}
+		if _, err = readSnapshot(profile, id); err != nil {
+			return err
+		}
+		if context.Args[0] == "restore" {
+			return restoreSnapshot(context, profile, id)
+		}
+		return deleteSnapshot(context, profile, id)
+	}
+
+	return errors.New("unknown snapshot command " + context.Args[0])
+}
+
+func createSnapshot(context ExecutionContext, profile string, message string) error {
+
+	snapshots, err := readSnapshots(profile)
+
+	if err != nil {
+		return err
+	}
+
+	id := 1
+	if len(snapshots) > 0 {
+		id = snapshots[len(snapshots)-1].Id + 1
+	}
+
+	if context.DryRun {
+		fmt.Fprintln(out, "would create snapshot "+strconv.Itoa(id)+" of "+profile)
+		return nil
+	}
+
+	if err = os.MkdirAll(snapshotObjectFolder(profile), 0775); err != nil {
+		return err
+	}
+
+	entry := Snapshot{Time: time.Now(), Message: message}
+
+	backup, err := ioutil.TempFile(snapshotFolder(profile), "database")
+
+	if err != nil {
+		return err
+	}
+
+	backup.Close()
+	defer os.Remove(backup.Name())
+
+	if err = backupDatabase(filepath.Join(profile, "Presets.db"), backup.Name()); err != nil {
+		return errors.New("failed to back up database: " + err.Error())
+	}
+
+	stored := 0
+
+	if entry.Database, err = storeSnapshotObject(profile, backup.Name(), &stored); err != nil {
+		return err
+	}
+
+	presets := filepath.Join(profile, PresetsFolder)
+
+	err = filepath.Walk(presets, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(presets, path)
+		if path == presets {
+			return nil
+		}
+		if info.IsDir() {
+			entry.Folders = append(entry.Folders, filepath.ToSlash(rel))
+			return nil
+		}
+		hash, err := storeSnapshotObject(profile, path, &stored)
+		if err != nil {
+			return err
+		}
+		entry.Files = append(entry.Files, SnapshotFile{Path: filepath.ToSlash(rel), Hash: hash})
+		return nil
+	})
+
+	if err != nil {
+		return errors.New("failed to create snapshot: " + err.Error())
+	}
+
+	data, err := json.MarshalIndent(entry, "", "\t")
+
+	if err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(snapshotFile(profile, id), data, 0664); err != nil {
+		return errors.New("failed to create snapshot: " + err.Error())
+	}
+
+	fmt.Fprintln(out, "created snapshot "+strconv.Itoa(id)+" of "+strconv.Itoa(len(entry.Files))+" file(s), "+strconv.Itoa(stored)+" new")
+
+	return nil
+}
+
+// storeSnapshotObject copies a file to the object folder unless a file with
+// the same contents is already stored, and returns the hash of the file.
+func storeSnapshotObject(profile string, file string, stored *int) (string, error) {
+
+	data, err := ioutil.ReadFile(file)
+
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	object := snapshotObjectFile(profile, hash)
+
+	if isFile(object) {
+		return hash, nil
+	}
+
+	if err = os.MkdirAll(filepath.Dir(object), 0775); err != nil {
+		return "", err
+	}
+
+	temp := object + ".tmp"
+
+	if err = ioutil.WriteFile(temp, data, 0664); err != nil {
+		return "", err
+	}
+
+	if err = os.Rename(temp, object); err != nil {
+		os.Remove(temp)
+		return "", err
+	}
+
+	*stored++
+
+	return hash, nil
+}
+
+func listSnapshots(profile string) error {
+
+	snapshots, err := readSnapshots(profile)
+
+	if err != nil {
+		return err
+	}
+
+	if len(snapshots) == 0 {
+		fmt.Fprintln(out, "no snapshots found")
+		return nil
+	}
+
+	for _, entry := range snapshots {
+		line := fmt.Sprintf("%4d  %s  %d file(s)", entry.Id, entry.Time.Format("2006-01-02 15:04:05"), len(entry.Files))
+		if entry.Message != "" {
+			line += "  " + entry.Message
+		}
+		fmt.Fprintln(out, line)
+	}
+
+	return nil
+}
+
+// restoreSnapshot builds the Presets tree and database of the snapshot next
+// to those of the profile, then swaps them in.  If either cannot be moved
+// into place the profile is left as it was.  Restoring is not journaled, so
+// the current state is saved in a new snapshot first.
+func restoreSnapshot(context ExecutionContext, profile string, id int) error {
+
+	entry, err := readSnapshot(profile, id)
+
+	if err != nil {
+		return err
+	}
+
+	if context.DryRun {
+		fmt.Fprintln(out, "would restore snapshot "+strconv.Itoa(id)+" to "+profile)
+		return nil
+	}
+
+	if err = createSnapshot(context, profile, "before restoring snapshot "+strconv.Itoa(id)); err != nil {
+		return err
+	}
+
+	staging, err := ioutil.TempDir(filepath.Join(profile, AmptFolder), "restore")
+
+	if err != nil {
+		return err
+	}
+
+	if err = stageSnapshot(profile, entry, staging); err != nil {
+		os.RemoveAll(staging)
+		return err
+	}
+
+	previous := filepath.Join(staging, "previous")
+
+	// the write ahead log of the current database must not be applied to
+	// the restored one
+	names := []string{PresetsFolder, "Presets.db", "Presets.db-wal", "Presets.db-shm"}
+	var moved []string
+
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(profile, name)); os.IsNotExist(err) {
+			continue
+		}
+		if err = os.Rename(filepath.Join(profile, name), filepath.Join(previous, name)); err != nil {
+			return failRestore(staging, rollbackRestore(profile, previous, moved, nil), err)
+		}
+		moved = append(moved, name)
+	}
+
+	var restored []string
+
+	for _, name := range names[:2] {
+		if err = os.Rename(filepath.Join(staging, name), filepath.Join(profile, name)); err != nil {
+			return failRestore(staging, rollbackRestore(profile, previous, moved, restored), err)
+		}
+		restored = append(restored, name)
+	}
+
+	os.RemoveAll(staging)
+
+	fmt.Fprintln(out, "restored snapshot "+strconv.Itoa(id)+" of "+strconv.Itoa(len(entry.Files))+" file(s)")
+
+	// the journal describes changes to the replaced files and database, so
+	// undoing them now would corrupt the restored profile
+	if entries, _ := readJournal(profile); len(entries) > 0 {
+		if err = os.RemoveAll(journalFolder(profile)); err != nil {
+			return errors.New("failed to clear history: " + err.Error())
+		}
+		fmt.Fprintln(out, "cleared "+strconv.Itoa(len(entries))+" operation(s) from the history; they cannot be undone after a restore")
+	}
+
+	return nil
+}
+
+// stageSnapshot writes the Presets tree and database of a snapshot to the
+// staging folder, along with an empty folder for the current ones.
+func stageSnapshot(profile string, entry Snapshot, staging string) error {
+
+	presets := filepath.Join(staging, PresetsFolder)
+
+	if err := os.Mkdir(presets, 0775); err != nil {
+		return err
+	}
+
+	for _, folder := range entry.Folders {
+		if err := os.MkdirAll(filepath.Join(presets, filepath.FromSlash(folder)), 0775); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range entry.Files {
+		if err := restoreSnapshotObject(profile, file.Hash, filepath.Join(presets, filepath.FromSlash(file.Path))); err != nil {
+			return err
+		}
+	}
+
+	if err := restoreSnapshotObject(profile, entry.Database, filepath.Join(staging, "Presets.db")); err != nil {
+		return err
+	}
+
+	return os.Mkdir(filepath.Join(staging, "previous"), 0775)
+}
+
+// failRestore removes the staging folder unless the rollback failed, in
+// which case it holds the only copy of the original Presets tree and
+// database.
+func failRestore(staging string, rollbackErr error, err error) error {
+	if rollbackErr != nil {
+		return errors.New("failed to restore snapshot: " + err.Error() + "; failed to put back the original presets: " + rollbackErr.Error() + "; they are in " + filepath.Join(staging, "previous"))
+	}
+	os.RemoveAll(staging)
+	return errors.New("failed to restore snapshot: " + err.Error())
+}
+
+func restoreSnapshotObject(profile string, hash string, target string) error {
+
+	source, err := os.Open(snapshotObjectFile(profile, hash))
+
+	if err != nil {
+		return errors.New("snapshot object " + hash + " is missing")
+	}
+
+	defer source.Close()
+
+	if err = os.MkdirAll(filepath.Dir(target), 0775); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0664)
+
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.New()
+
+	_, err = io.Copy(io.MultiWriter(file, sum), source)
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(sum.Sum(nil)) != hash {
+		return errors.New("snapshot object " + hash + " is corrupt")
+	}
+
+	return nil
+}
+
+func rollbackRestore(profile string, previous string, moved []string, restored []string) error {
+	var failed error
+	for _, name := range restored {
+		if err := os.RemoveAll(filepath.Join(profile, name)); err != nil {
+			failed = err
+		}
+	}
+	for _, name := range moved {
+		if err := os.Rename(filepath.Join(previous, name), filepath.Join(profile, name)); err != nil {
+			failed = err
+		}
+	}
+	return failed
+}
+
+// deleteSnapshot removes a snapshot and the objects no other snapshot uses.
+func deleteSnapshot(context ExecutionContext, profile string, id int) error {
+
+	if context.DryRun {
+		fmt.Fprintln(out, "would delete snapshot "+strconv.Itoa(id)+" of "+profile)
+		return nil
+	}
+
+	if err := os.Remove(snapshotFile(profile, id)); err != nil {
+		return errors.New("failed to delete snapshot: " + err.Error())
+	}
+
+	snapshots, err := readSnapshots(profile)
+
+	if err != nil {
+		return err
+	}
+
+	used := map[string]bool{}
+
+	for _, entry := range snapshots {
+		used[entry.Database] = true
+		for _, file := range entry.Files {
+			used[file.Hash] = true
+		}
+	}
+
+	objects, _ := filepath.Glob(filepath.Join(snapshotObjectFolder(profile), "*", "*"))
+
+	for _, object := range objects {
+		if !used[filepath.Base(object)] {
+			os.Remove(object)
+			os.Remove(filepath.Dir(object))
+		}
+	}
+
+	fmt.Fprintln(out, "deleted snapshot "+strconv.Itoa(id))
+
+	return nil
+}
+
+func snapshotFolder(profile string) string {
+	return filepath.Join(profile, AmptFolder, SnapshotFolder)
+}
+
+func snapshotObjectFolder(profile string) string {
+	return filepath.Join(snapshotFolder(profile), SnapshotObjectFolder)
+}
+
+func snapshotObjectFile(profile string, hash string) string {
+	return filepath.Join(snapshotObjectFolder(profile), hash[:2], hash)
+}
+
+func snapshotFile(profile string, id int) string {
+	return filepath.Join(snapshotFolder(profile), fmt.Sprintf("%06d.json", id))
+}
+
+func readSnapshot(profile string, id int) (Snapshot, error) {
+
+	var entry Snapshot
+
+	data, err := ioutil.ReadFile(snapshotFile(profile, id))
+
+	if err != nil {
+		return entry, errors.New("snapshot " + strconv.Itoa(id) + " not found")
+	}
+
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return entry, errors.New("invalid snapshot " + snapshotFile(profile, id) + ": " + err.Error())
+	}
+
+	entry.Id = id
+
+	return entry, nil
+}
+
+func readSnapshots(profile string) ([]Snapshot, error) {
+
+	files, _ := filepath.Glob(filepath.Join(snapshotFolder(profile), "*.json"))
+
+	var snapshots []Snapshot
+
+	for _, file := range files {
+
+		id, err := strconv.Atoi(strings.TrimSuffix(filepath.Base(file), ".json"))
+
+		if err != nil {
+			continue
+		}
+
+		entry, err := readSnapshot(profile, id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, entry)
+	}
+
+	sort.Slice(snapshots, func(i, k int) bool {
+		return snapshots[i].Id < snapshots[k].Id
+	})
+
+	return snapshots, nil
+}
@@ -0,0 +1,282 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+type LibraryStats struct {
+	Presets    int             `json:"presets"`
+	Folders    []StatCount     `json:"folders"`
+	Chains     []StatCount     `json:"chains"`
+	Gear       []GearUsage     `json:"gear"`
+	Categories []GearUsage     `json:"categories"`
+	Slots      []SlotOccupancy `json:"slots"`
+	Ratings    []StatCount     `json:"ratings"`
+	Favorites  []StatCount     `json:"favorites"`
+}
+
+type StatCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type GearUsage struct {
+	Type     string `json:"type"`
+	GUID     string `json:"guid,omitempty"`
+	Model    string `json:"model,omitempty"`
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+type SlotOccupancy struct {
+	Block    string `json:"block"`
+	Presets  int    `json:"presets"`
+	Occupied int    `json:"occupied"`
+	Slots    int    `json:"slots"`
+}
+
+func stats(context ExecutionContext) error {
+
+	if len(context.Args) < 1 {
+		return errors.New("stats requires a preset folder")
+	}
+
+	root, _ := filepath.Abs(context.Args[0])
+
+	matches, err := resolveToMatches(root, true, true)
+
+	if err != nil {
+		return err
+	}
+
+	if context.Database == nil {
+		context.Database = openProfileDatabase(root)
+		if context.Database != nil {
+			defer context.Database.Close()
+		}
+	}
+
+	var library LibraryStats
+
+	folders := map[string]int{}
+	chains := map[string]int{}
+	ratings := map[string]int{}
+	favorites := map[string]int{}
+	gear := map[string]*GearUsage{}
+	categories := map[string]*GearUsage{}
+	slots := map[string]*SlotOccupancy{}
+	var blockOrder []string
+
+	rating, _ := lookupMetaField("Rating")
+	favorite, _ := lookupMetaField("Favorite")
+
+	for _, match := range sortMatches(matches) {
+
+		if !isValidPresetName(match) {
+			continue
+		}
+
+		preset, err := readPresetFile(match)
+
+		if err != nil {
+			return err
+		}
+
+		library.Presets++
+		folders[presetFolder(match)]++
+
+		chain := ""
+		if element := preset.child("Chain"); element != nil {
+			chain = element.attr("Preset")
+		}
+		chains[statName(chain)]++
+
+		counted := map[string]bool{}
+
+		for _, ref := range presetGear(preset) {
+
+			if ref.GUID == "" || isEmptyFx(ref.GUID) {
+				continue
+			}
+
+			category := catalogCategory(GearTypeKinds[ref.Type], ref.GUID)
+
+			if !counted[ref.Type+ref.GUID] {
+				counted[ref.Type+ref.GUID] = true
+				if gear[ref.Type+ref.GUID] == nil {
+					gear[ref.Type+ref.GUID] = &GearUsage{Type: ref.Type, GUID: ref.GUID, Model: ref.Name, Category: category}
+				}
+				gear[ref.Type+ref.GUID].Count++
+			}
+
+			if !counted[ref.Type+"/"+category] {
+				counted[ref.Type+"/"+category] = true
+				if categories[ref.Type+"/"+category] == nil {
+					categories[ref.Type+"/"+category] = &GearUsage{Type: ref.Type, Category: category}
+				}
+				categories[ref.Type+"/"+category].Count++
+			}
+		}
+
+		if schema, err := schemaForFormat(preset.attr("Format")); err == nil {
+			for _, block := range schema.chainBlocks(chain) {
+				element := preset.child(block.Name)
+				if block.Kind != FxBlock || element == nil {
+					continue
+				}
+				if slots[block.Name] == nil {
+					slots[block.Name] = &SlotOccupancy{Block: block.Name}
+					blockOrder = append(blockOrder, block.Name)
+				}
+				slots[block.Name].Presets++
+				slots[block.Name].Occupied += len(occupiedFxSlots(element, block))
+				slots[block.Name].Slots += block.Slots
+			}
+		}
+
+		value, err := getMeta(context, match, preset, rating)
+		if err != nil {
+			return err
+		}
+		ratings[statName(value)]++
+
+		value, err = getMeta(context, match, preset, favorite)
+		if err != nil {
+			return err
+		}
+		favorites[statName(value)]++
+	}
+
+	if library.Presets == 0 {
+		return errors.New("no presets found in " + context.Args[0])
+	}
+
+	library.Folders = sortedCounts(folders)
+	library.Chains = sortedCounts(chains)
+	library.Ratings = sortedCounts(ratings)
+	library.Favorites = sortedCounts(favorites)
+	library.Gear = sortedUsage(gear)
+	library.Categories = sortedUsage(categories)
+
+	sort.Strings(blockOrder)
+	for _, name := range blockOrder {
+		library.Slots = append(library.Slots, *slots[name])
+	}
+
+	if *context.Options["json"].(*bool) {
+		data, err := json.MarshalIndent(library, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	return printStats(library)
+}
+
+func printStats(library LibraryStats) error {
+
+	fmt.Fprintln(out, strconv.Itoa(library.Presets)+" preset(s) in "+strconv.Itoa(len(library.Folders))+" folder(s)")
+
+	writer := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+
+	printCounts(writer, "FOLDER", library.Folders)
+	printCounts(writer, "CHAIN", library.Chains)
+
+	fmt.Fprintln(writer, "\nTYPE\tMODEL\tCATEGORY\tPRESETS")
+	for _, usage := range library.Gear {
+		fmt.Fprintln(writer, usage.Type+"\t"+usage.Model+"\t"+statName(usage.Category)+"\t"+strconv.Itoa(usage.Count))
+	}
+
+	fmt.Fprintln(writer, "\nTYPE\tCATEGORY\tPRESETS")
+	for _, usage := range library.Categories {
+		fmt.Fprintln(writer, usage.Type+"\t"+statName(usage.Category)+"\t"+strconv.Itoa(usage.Count))
+	}
+
+	fmt.Fprintln(writer, "\nBLOCK\tPRESETS\tOCCUPIED\tSLOTS")
+	for _, occupancy := range library.Slots {
+		fmt.Fprintln(writer, occupancy.Block+"\t"+strconv.Itoa(occupancy.Presets)+"\t"+strconv.Itoa(occupancy.Occupied)+"\t"+strconv.Itoa(occupancy.Slots))
+	}
+
+	printCounts(writer, "RATING", library.Ratings)
+	printCounts(writer, "FAVORITE", library.Favorites)
+
+	return writer.Flush()
+}
+
+func printCounts(writer *tabwriter.Writer, heading string, counts []StatCount) {
+	fmt.Fprintln(writer, "\n"+heading+"\tPRESETS")
+	for _, count := range counts {
+		fmt.Fprintln(writer, count.Name+"\t"+strconv.Itoa(count.Count))
+	}
+}
+
+func statName(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+func sortedCounts(counts map[string]int) []StatCount {
+	var sorted []StatCount
+	for name, count := range counts {
+		sorted = append(sorted, StatCount{Name: name, Count: count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+func sortedUsage(usage map[string]*GearUsage) []GearUsage {
+	var sorted []GearUsage
+	for _, u := range usage {
+		sorted = append(sorted, *u)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if gearTypeIndex(sorted[i].Type) != gearTypeIndex(sorted[j].Type) {
+			return gearTypeIndex(sorted[i].Type) < gearTypeIndex(sorted[j].Type)
+		}
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Model+sorted[i].Category < sorted[j].Model+sorted[j].Category
+	})
+	return sorted
+}
+
+func gearTypeIndex(gearType string) int {
+	for i, t := range GearTypes {
+		if t == gearType {
+			return i
+		}
+	}
+	return len(GearTypes)
+}
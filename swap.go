@@ -0,0 +1,315 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type GearLocation struct {
+	Block BlockSchema
+	Slot  int
+}
+
+func swapGear(context ExecutionContext) error {
+	return reorderGear(context, true)
+}
+
+func moveGear(context ExecutionContext) error {
+	return reorderGear(context, false)
+}
+
+func reorderGear(context ExecutionContext, swap bool) error {
+
+	if len(context.Args) < 3 {
+		if swap {
+			return errors.New("swap requires a preset and two blocks or slots")
+		}
+		return errors.New("move gear requires a preset, a source and a destination")
+	}
+
+	recursive := *context.Options["recursive"].(*bool)
+
+	matches, err := resolveToMatches(context.Args[0], recursive, true)
+
+	if err != nil {
+		return err
+	}
+
+	presets := map[string]*Element{}
+
+	for _, match := range matches {
+
+		preset, err := readPresetFile(match)
+
+		if err != nil {
+			return err
+		}
+
+		schema, err := schemaForFormat(preset.attr("Format"))
+
+		if err != nil {
+			return err
+		}
+
+		from, err := parseGearLocation(schema, context.Args[1])
+
+		if err != nil {
+			return err
+		}
+
+		to, err := parseGearLocation(schema, context.Args[2])
+
+		if err != nil {
+			return err
+		}
+
+		if swap {
+			err = swapLocations(preset, schema, from, to, *context.Options["nocabwithamp"].(*bool))
+		} else {
+			err = moveLocation(preset, from, to)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		presets[match] = preset
+	}
+
+	for _, match := range matches {
+		if err = context.writePreset(match, presets[match]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseGearLocation(schema PresetSchema, ref string) (GearLocation, error) {
+	parts := strings.SplitN(ref, ".", 2)
+	block, ok := schema.block(parts[0])
+	if !ok {
+		return GearLocation{}, errors.New("unknown block " + parts[0])
+	}
+	if len(parts) == 1 {
+		return GearLocation{Block: block, Slot: -1}, nil
+	}
+	slot, err := strconv.Atoi(strings.TrimPrefix(parts[1], "Slot"))
+	if block.Kind != FxBlock || !strings.HasPrefix(parts[1], "Slot") || err != nil || slot < 0 || slot >= block.Slots {
+		return GearLocation{}, errors.New("invalid slot " + ref)
+	}
+	return GearLocation{Block: block, Slot: slot}, nil
+}
+
+func swapLocations(preset *Element, schema PresetSchema, a GearLocation, b GearLocation, noCab bool) error {
+
+	if (a.Slot < 0) != (b.Slot < 0) {
+		return errors.New("cannot swap a block with a slot")
+	}
+
+	if a.Block.Kind != b.Block.Kind {
+		return errors.New("incompatible slots")
+	}
+
+	aElement := preset.child(a.Block.Name)
+	bElement := preset.child(b.Block.Name)
+
+	if aElement == nil || bElement == nil {
+		return errors.New("block not found in preset")
+	}
+
+	switch a.Block.Kind {
+	case AmpBlock:
+		preset.replaceChild(a.Block.Name, renamed(bElement, a.Block.Name))
+		preset.replaceChild(b.Block.Name, renamed(aElement, b.Block.Name))
+		if !noCab {
+			aCab, _ := schema.block(a.Block.Pair)
+			bCab, _ := schema.block(b.Block.Pair)
+			return swapLocations(preset, schema, GearLocation{Block: aCab, Slot: -1}, GearLocation{Block: bCab, Slot: -1}, true)
+		}
+	case CabBlock:
+		preset.replaceChild(a.Block.Name, renamed(bElement, a.Block.Name))
+		preset.replaceChild(b.Block.Name, renamed(aElement, b.Block.Name))
+	case FxBlock:
+		aSlots := fxSlots(aElement, a.Block)
+		bSlots := fxSlots(bElement, b.Block)
+		if a.Slot >= 0 {
+			if err := checkFxBlock(aSlots[a.Slot:a.Slot+1], b.Block); err != nil {
+				return err
+			}
+			if err := checkFxBlock(bSlots[b.Slot:b.Slot+1], a.Block); err != nil {
+				return err
+			}
+			if a.Block.Name == b.Block.Name {
+				aSlots[a.Slot], aSlots[b.Slot] = aSlots[b.Slot], aSlots[a.Slot]
+				setFxSlots(aElement, a.Block, aSlots)
+			} else {
+				aSlots[a.Slot], bSlots[b.Slot] = bSlots[b.Slot], aSlots[a.Slot]
+				setFxSlots(aElement, a.Block, aSlots)
+				setFxSlots(bElement, b.Block, bSlots)
+			}
+			return nil
+		}
+		if err := checkFxBlock(aSlots, b.Block); err != nil {
+			return err
+		}
+		if err := checkFxBlock(bSlots, a.Block); err != nil {
+			return err
+		}
+		aFitted, err := fitFxSlots(aSlots, b.Block)
+		if err != nil {
+			return err
+		}
+		bFitted, err := fitFxSlots(bSlots, a.Block)
+		if err != nil {
+			return err
+		}
+		aElement, bElement = renamed(bElement, a.Block.Name), renamed(aElement, b.Block.Name)
+		setFxSlots(aElement, a.Block, bFitted)
+		setFxSlots(bElement, b.Block, aFitted)
+		preset.replaceChild(a.Block.Name, aElement)
+		preset.replaceChild(b.Block.Name, bElement)
+	default:
+		return errors.New("swap not supported for " + a.Block.Name)
+	}
+
+	return nil
+}
+
+func moveLocation(preset *Element, from GearLocation, to GearLocation) error {
+
+	if from.Block.Kind != FxBlock || to.Block.Kind != FxBlock {
+		return errors.New("move gear only supported for effects; use swap for amps and cabs")
+	}
+
+	if from.Slot < 0 && from.Block.Name == to.Block.Name {
+		return errors.New("cannot move " + from.Block.Name + " within itself")
+	}
+
+	fromElement := preset.child(from.Block.Name)
+	toElement := preset.child(to.Block.Name)
+
+	if fromElement == nil || toElement == nil {
+		return errors.New("block not found in preset")
+	}
+
+	fromSlots := fxSlots(fromElement, from.Block)
+
+	var moving []FxSlot
+
+	if from.Slot < 0 {
+		moving = occupiedFxSlots(fromElement, from.Block)
+		fromSlots = nil
+	} else {
+		if isEmptyFx(fromSlots[from.Slot].GUID) {
+			return errors.New(from.Block.Name + "." + slotName(from.Slot) + " is empty")
+		}
+		moving = []FxSlot{fromSlots[from.Slot]}
+		fromSlots[from.Slot] = FxSlot{}
+	}
+
+	if err := checkFxBlock(moving, to.Block); err != nil {
+		return err
+	}
+
+	toSlots := fromSlots
+
+	if from.Block.Name != to.Block.Name {
+		setFxSlots(fromElement, from.Block, fromSlots)
+		toSlots = fxSlots(toElement, to.Block)
+	} else if from.Slot >= 0 {
+		toSlots = append(fromSlots[:from.Slot:from.Slot], fromSlots[from.Slot+1:]...)
+	}
+
+	position := to.Slot
+
+	if position < 0 {
+		position = 0
+		for i, slot := range toSlots {
+			if !isEmptyFx(slot.GUID) {
+				position = i + 1
+			}
+		}
+	}
+
+	var slots []FxSlot
+	slots = append(slots, toSlots[:position]...)
+	slots = append(slots, moving...)
+	slots = append(slots, toSlots[position:]...)
+
+	for i := len(slots) - 1; i >= position+len(moving) && len(slots) > to.Block.Slots; i-- {
+		if isEmptyFx(slots[i].GUID) {
+			slots = append(slots[:i], slots[i+1:]...)
+		}
+	}
+
+	for i := position - 1; i >= 0 && len(slots) > to.Block.Slots; i-- {
+		if isEmptyFx(slots[i].GUID) {
+			slots = append(slots[:i], slots[i+1:]...)
+		}
+	}
+
+	if len(slots) > to.Block.Slots {
+		return errors.New(to.Block.Name + " has only " + strconv.Itoa(to.Block.Slots) + " slots")
+	}
+
+	setFxSlots(toElement, to.Block, slots)
+
+	return nil
+}
+
+// fitFxSlots keeps effects in their slots where the target block has room
+// for them, otherwise they are moved up to fill the empty slots.
+func fitFxSlots(slots []FxSlot, block BlockSchema) ([]FxSlot, error) {
+	var occupied []FxSlot
+	fits := true
+	for i, slot := range slots {
+		if !isEmptyFx(slot.GUID) {
+			occupied = append(occupied, slot)
+			fits = fits && i < block.Slots
+		}
+	}
+	if fits {
+		return slots, nil
+	}
+	if len(occupied) > block.Slots {
+		return nil, errors.New(block.Name + " has only " + strconv.Itoa(block.Slots) + " slots")
+	}
+	return occupied, nil
+}
+
+// checkFxBlock refuses pedals in rack and loop blocks and rack effects in
+// stomp blocks.  Effects without a catalog category are allowed anywhere.
+func checkFxBlock(slots []FxSlot, block BlockSchema) error {
+	stomp := strings.Index(block.Name, "Stomp") == 0
+	for _, slot := range slots {
+		if isEmptyFx(slot.GUID) {
+			continue
+		}
+		category := catalogCategory("fx", slot.GUID)
+		if stomp && strings.HasPrefix(category, "Rack Effects/") {
+			return errors.New(FX[slot.GUID] + " is a rack effect and cannot go in " + block.Name)
+		}
+		if !stomp && strings.HasPrefix(category, "Pedals/") {
+			return errors.New(FX[slot.GUID] + " is a pedal and cannot go in " + block.Name)
+		}
+	}
+	return nil
+}
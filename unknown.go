@@ -0,0 +1,143 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+const MaxUnknownExamples = 3
+
+var GearTypeKinds = map[string]string{
+	"amp":     "amps",
+	"cab":     "cabs",
+	"speaker": "speakers",
+	"mic":     "mics",
+	"fx":      "fx",
+}
+
+type UnknownGear struct {
+	Type     string
+	GUID     string
+	Count    int
+	Examples []string
+}
+
+func unknownGear(context ExecutionContext) error {
+
+	if len(context.Args) < 1 {
+		return errors.New("unknown requires a preset folder")
+	}
+
+	root, _ := filepath.Abs(context.Args[0])
+
+	matches, err := resolveToMatches(root, true, true)
+
+	if err != nil {
+		return err
+	}
+
+	found := map[string]*UnknownGear{}
+
+	for _, match := range matches {
+
+		if !isValidPresetName(match) {
+			continue
+		}
+
+		preset, err := readPresetFile(match)
+
+		if err != nil {
+			return err
+		}
+
+		counted := map[string]bool{}
+
+		for _, gear := range allPresetGear(preset) {
+
+			if gear.GUID == "" || isEmptyFx(gear.GUID) || gear.Name != gear.GUID || counted[gear.Type+gear.GUID] {
+				continue
+			}
+
+			counted[gear.Type+gear.GUID] = true
+
+			unknown := found[gear.Type+gear.GUID]
+
+			if unknown == nil {
+				unknown = &UnknownGear{Type: gear.Type, GUID: gear.GUID}
+				found[gear.Type+gear.GUID] = unknown
+			}
+
+			unknown.Count++
+
+			if len(unknown.Examples) < MaxUnknownExamples {
+				example, _ := filepath.Rel(root, match)
+				unknown.Examples = append(unknown.Examples, example)
+			}
+		}
+	}
+
+	var unknowns []*UnknownGear
+
+	for _, unknown := range found {
+		unknowns = append(unknowns, unknown)
+	}
+
+	sort.Slice(unknowns, func(i, j int) bool {
+		if unknowns[i].Type != unknowns[j].Type {
+			return unknowns[i].Type < unknowns[j].Type
+		}
+		if unknowns[i].Count != unknowns[j].Count {
+			return unknowns[i].Count > unknowns[j].Count
+		}
+		return unknowns[i].GUID < unknowns[j].GUID
+	})
+
+	if len(unknowns) == 0 {
+		fmt.Fprintln(out, "no unknown gear found")
+		return nil
+	}
+
+	var stub GearCatalog
+
+	for _, unknown := range unknowns {
+		fmt.Fprintln(out, unknown.Type+" "+unknown.GUID+" in "+strconv.Itoa(unknown.Count)+" preset(s)")
+		for _, example := range unknown.Examples {
+			fmt.Fprintln(out, "    "+example)
+		}
+		stub.put(GearTypeKinds[unknown.Type], CatalogEntry{GUID: unknown.GUID})
+	}
+
+	output := *context.Options["output"].(*string)
+
+	if output == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(stub, "", "\t")
+
+	if err != nil {
+		return err
+	}
+
+	return context.writeFile(output, append(data, '\n'), 0664)
+}
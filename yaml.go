@@ -0,0 +1,163 @@
+/*
+Copyright (C) 2021 fcbrooks
+
+    This program is free software: you can redistribute it and/or modify
+    it under the terms of the GNU General Public License as published by
+    the Free Software Foundation, either version 3 of the License, or
+    (at your option) any later version.
+
+    This program is distributed in the hope that it will be useful,
+    but WITHOUT ANY WARRANTY; without even the implied warranty of
+    MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+    GNU General Public License for more details.
+
+    You should have received a copy of the GNU General Public License
+    along with this program.  If not, see <https://www.gnu.org/licenses/>.
+*/
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Documents are converted between JSON and YAML through yaml.Node trees so
+// the order of attributes is kept both ways.
+
+func jsonToYAML(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	node, err := readJSONNode(decoder)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err = encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err = encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func yamlToJSON(data []byte) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 {
+		return nil, errors.New("empty document")
+	}
+	var buf bytes.Buffer
+	if err := writeJSONNode(&buf, document.Content[0]); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func readJSONNode(decoder *json.Decoder) (*yaml.Node, error) {
+
+	token, err := decoder.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		if value == '[' {
+			node.Kind = yaml.SequenceNode
+		}
+		for decoder.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key.(string)})
+			}
+			child, err := readJSONNode(decoder)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, child)
+		}
+		if _, err = decoder.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value, Style: yaml.DoubleQuotedStyle}, nil
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: value.String()}, nil
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!float", Value: value.String()}, nil
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(value)}, nil
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+
+	return nil, io.ErrUnexpectedEOF
+}
+
+func writeJSONNode(buf *bytes.Buffer, node *yaml.Node) error {
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		return writeJSONNode(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(node.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+			if err := writeJSONNode(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, child := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeJSONNode(buf, child); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case yaml.ScalarNode:
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return errors.New("line " + strconv.Itoa(node.Line) + ": " + err.Error())
+		}
+		if number, ok := value.(float64); ok && node.ShortTag() == "!!float" {
+			// json.Marshal would write large and small numbers with exponents
+			value = json.Number(strconv.FormatFloat(number, 'f', -1, 64))
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return errors.New("line " + strconv.Itoa(node.Line) + ": " + err.Error())
+		}
+		buf.Write(data)
+	default:
+		return errors.New("line " + strconv.Itoa(node.Line) + ": unsupported YAML node")
+	}
+
+	return nil
+}